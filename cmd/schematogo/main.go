@@ -0,0 +1,65 @@
+// Command schematogo generates the Go model structs of a MongoDB $jsonSchema.
+//
+//   schematogo -in validator.json -package model -type User -out user.go
+//
+// The input can be the $jsonSchema document, or a document with the $jsonSchema or
+// validator keys, as returned by db.getCollectionInfos(). Without -in the schema is read
+// from the standard input and without -out the source is written to the standard output.
+//
+// O comando schematogo gera as structs de modelo Go de um $jsonSchema do MongoDB.
+//
+//   schematogo -in validator.json -package model -type User -out user.go
+//
+// A entrada pode ser o documento $jsonSchema, ou um documento com as chaves $jsonSchema
+// ou validator, como retornado por db.getCollectionInfos(). Sem -in o esquema é lido da
+// entrada padrão e sem -out o código é escrito na saída padrão.
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	iotmakerdbmongodbutilschema "github.com/helmutkemper/iotmaker.db.mongodb.util.schema.workingInProgress"
+)
+
+func main() {
+	var in = flag.String("in", "", "json file of the schema (default: standard input)")
+	var out = flag.String("out", "", "go file generated (default: standard output)")
+	var packageName = flag.String("package", "model", "package name of the go file")
+	var typeName = flag.String("type", "Document", "name of the struct of the root document")
+	flag.Parse()
+
+	var err error
+	var data []byte
+	if *in == "" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(*in)
+	}
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	var schema = iotmakerdbmongodbutilschema.MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON(data)
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	var source []byte
+	source, err = schema.GenerateGoSource(*packageName, *typeName)
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(source)
+	} else {
+		err = ioutil.WriteFile(*out, source, 0644)
+	}
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+}
@@ -47,6 +47,9 @@ var dateLayout = "Jan 2, 2006 at 3:04pm (MST)"
 //   time.StampMilli = "Jan _2 15:04:05.000"
 //   time.StampMicro = "Jan _2 15:04:05.000000"
 //   time.StampNano  = "Jan _2 15:04:05.000000000"
+//
+// Deprecated: the layout is global and races with the parse of other schemas, use
+// ParseOptions.DateLayouts
 func DefineNewDateLayout(layout string) {
 	dateLayout = layout
 }
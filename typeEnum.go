@@ -14,13 +14,19 @@ func (el *Enum) Verify(value interface{}) (err error) {
 		return
 	}
 
+	// (English): arrays and documents can not be compared with == and the numbers of the
+	// json schema are float64, so the values are compared with the BSON rules
+	//
+	// (Português): arrays e documentos não podem ser comparados com == e os números do
+	// esquema json são float64, por isto, os valores são comparados com as regras do BSON
+	var common TypeBsonCommonToAllTypes
 	for _, v := range el.values {
-		if v == value {
+		if common.parentCompareValues(v, value) == true {
 			return
 		}
 	}
 
-	err = errors.New("the value does not match any value contained in the array")
+	err = newViolation("enum", el.values, value, "the value does not match any value contained in the array")
 	return
 }
 
@@ -0,0 +1,71 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestEnum_Verify(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "properties": {
+      "pair": { "enum": [[1, 2], "none"] },
+      "point": { "enum": [{"x": 1}, null] },
+      "level": { "enum": [1, 2.5] },
+      "size": { "bsonType": "int", "enum": [1, 2] }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var document = bson.D{
+		{Key: "pair", Value: bson.A{int32(1), int64(2)}},
+		{Key: "point", Value: bson.D{{Key: "x", Value: int32(1)}}},
+		{Key: "level", Value: int32(1)},
+		{Key: "size", Value: int32(2)},
+	}
+
+	var raw bson.Raw
+	raw, err = bson.Marshal(document)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var validList = []interface{}{
+		document,
+		raw,
+		map[string]interface{}{"pair": []int{1, 2}, "point": map[string]interface{}{"x": 1.0}, "level": 2.5},
+		map[string]interface{}{"pair": "none", "point": nil, "level": int64(1), "size": 1},
+	}
+
+	for _, value := range validList {
+		var result = schema.Validate(value)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", value, result.Errors())
+		}
+	}
+
+	var result = schema.Validate(bson.D{
+		{Key: "pair", Value: bson.A{2, 1}},
+		{Key: "point", Value: bson.M{"x": 1, "y": 2}},
+		{Key: "level", Value: int32(2)},
+		{Key: "size", Value: int32(3)},
+	})
+
+	var expected = []string{"pair", "point", "level", "size"}
+	if len(result.Violations) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Errors())
+	}
+
+	for k := range expected {
+		if result.Violations[k].Path != expected[k] || result.Violations[k].Keyword != "enum" {
+			t.Errorf("expected %v, got %v", expected, result.Errors())
+			break
+		}
+	}
+}
@@ -26,13 +26,13 @@ import "fmt"
       }
     }
   }
-
-  todo: tipo array 'MaxItems' necessita de 'has set'
-  todo: tipos int, double, decimal, long 'Maximum' necessita de 'has set'
 */
 
 type MongoDBJsonSchema struct {
 	TypeBsonObject
+
+	// copy of the schema used by Populate(), used by Compile()
+	source map[string]interface{}
 }
 
 func (el *MongoDBJsonSchema) walking(key string, properties map[string]map[string]BsonType) {
@@ -57,6 +57,13 @@ func (el *MongoDBJsonSchema) walking(key string, properties map[string]map[strin
 	}
 }
 
+// VerifyErros (English): Prints the errors found by VerifyRules()
+//
+// Deprecated: use Validate(), it returns the violations instead of printing them
+//
+// VerifyErros (Português): Imprime os erros encontrados por VerifyRules()
+//
+// Obsoleto: use Validate(), retorna as violações em vez de imprimi-las
 func (el *MongoDBJsonSchema) VerifyErros() {
 	for _, err := range el.ErrorList {
 		fmt.Printf("error: %v\n", err.Error())
@@ -0,0 +1,104 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"errors"
+)
+
+// Populate (English): Populates the rules of the schema from the decoded json schema
+// and keeps a private copy of it for Compile()
+//
+// Populate (Português): Popula as regras do esquema a partir do json schema decodificado
+// e guarda uma cópia privada dele para Compile()
+func (el *MongoDBJsonSchema) Populate(schema map[string]interface{}) (err error) {
+	err = el.PopulateWithOptions(schema, ParseOptions{})
+	return
+}
+
+// PopulateWithOptions (English): Same as Populate(), but the parse options are applied
+// to all rules of the schema
+//
+// PopulateWithOptions (Português): Igual a Populate(), mas as opções de parser são
+// aplicadas a todas as regras do esquema
+func (el *MongoDBJsonSchema) PopulateWithOptions(schema map[string]interface{}, options ParseOptions) (err error) {
+	options.DateLayouts = append([]string(nil), options.DateLayouts...)
+
+	// (English): the global layout is copied once, so DefineNewDateLayout() does not race
+	// with the validation
+	//
+	// (Português): o layout global é copiado uma vez, assim DefineNewDateLayout() não
+	// concorre com a validação
+	if len(options.DateLayouts) == 0 {
+		options.DateLayouts = []string{dateLayout}
+	}
+
+	el.setParseOptions(options)
+	el.source = el.copySchema(schema).(map[string]interface{})
+	err = el.TypeBsonObject.Populate(schema)
+	return
+}
+
+// Compile (English): Turns the parsed schema into an immutable validator. The validator
+// has its own copy of all rules, so it can be shared between goroutines and is not
+// affected by later changes in the schema.
+//
+//   var schema = MongoDBJsonSchema{}
+//   err = schema.UnmarshalJSON(data)
+//   ...
+//   validator, err = schema.Compile()
+//   ...
+//   go func() { result := validator.Validate(documentA) }()
+//   go func() { result := validator.Validate(documentB) }()
+//
+// Compile (Português): Transforma o esquema em um validador imutável. O validador tem a
+// sua própria cópia de todas as regras, podendo ser compartilhado entre goroutines, e
+// não é afetado por mudanças posteriores no esquema.
+//
+//   var schema = MongoDBJsonSchema{}
+//   err = schema.UnmarshalJSON(data)
+//   ...
+//   validator, err = schema.Compile()
+//   ...
+//   go func() { result := validator.Validate(documentA) }()
+//   go func() { result := validator.Validate(documentB) }()
+func (el *MongoDBJsonSchema) Compile() (validator *Validator, err error) {
+	if el.source == nil {
+		err = errors.New("the schema must be populated before compile")
+		return
+	}
+
+	validator = &Validator{}
+	validator.root.setParseOptions(el.options)
+	err = validator.root.Populate(el.copySchema(el.source).(map[string]interface{}))
+	if err != nil {
+		validator = nil
+	}
+
+	return
+}
+
+// copySchema (English): Deep copy of a decoded json schema. Populate() functions keep
+// references to slices of the schema, such as enum, and some of them convert the values
+// in place.
+//
+// copySchema (Português): Cópia profunda de um json schema decodificado. As funções
+// Populate() guardam referências para slices do esquema, como enum, e algumas delas
+// convertem os valores no próprio slice.
+func (el *MongoDBJsonSchema) copySchema(value interface{}) (copied interface{}) {
+	switch converted := value.(type) {
+	case map[string]interface{}:
+		var newMap = make(map[string]interface{}, len(converted))
+		for k, v := range converted {
+			newMap[k] = el.copySchema(v)
+		}
+		return newMap
+
+	case []interface{}:
+		var newSlice = make([]interface{}, len(converted))
+		for k, v := range converted {
+			newSlice[k] = el.copySchema(v)
+		}
+		return newSlice
+	}
+
+	return value
+}
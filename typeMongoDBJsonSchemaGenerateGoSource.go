@@ -0,0 +1,476 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"bytes"
+	"errors"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// interfaceBsonMetadata (English): Implemented by all types through
+// TypeBsonCommonToAllTypes, returns the title and the description of the schema
+//
+// interfaceBsonMetadata (Português): Implementada por todos os tipos através de
+// TypeBsonCommonToAllTypes, retorna o título e a descrição do esquema
+type interfaceBsonMetadata interface {
+	getMetadata() (title, description string)
+}
+
+// getMetadata (English): Returns the title and the description of the schema
+//
+// getMetadata (Português): Retorna o título e a descrição do esquema
+func (el *TypeBsonCommonToAllTypes) getMetadata() (title, description string) {
+	return el.Title, el.Description
+}
+
+// goSourceScalarTypeList (English): Go type of each bsonType that is not a document or
+// an array, as decoded by the mongo driver
+//
+// goSourceScalarTypeList (Português): Tipo Go de cada bsonType que não é um documento ou
+// um array, como decodificado pelo driver do mongo
+var goSourceScalarTypeList = map[string]string{
+	"string":              "string",
+	"bool":                "bool",
+	"int":                 "int32",
+	"long":                "int64",
+	"double":              "float64",
+	"decimal":             "primitive.Decimal128",
+	"date":                "time.Time",
+	"objectId":            "primitive.ObjectID",
+	"timestamp":           "primitive.Timestamp",
+	"binData":             "[]byte",
+	"regex":               "primitive.Regex",
+	"javascript":          "primitive.JavaScript",
+	"symbol":              "primitive.Symbol",
+	"javascriptWithScope": "primitive.CodeWithScope",
+	"dbPointer":           "primitive.DBPointer",
+	"minKey":              "primitive.MinKey",
+	"maxKey":              "primitive.MaxKey",
+	"undefined":           "primitive.Undefined",
+	"generic":             "interface{}",
+}
+
+// goSourceInitialismList (English): Words written in upper case in Go names
+//
+// goSourceInitialismList (Português): Palavras escritas em maiúsculas em nomes Go
+var goSourceInitialismList = map[string]bool{
+	"api":  true,
+	"html": true,
+	"http": true,
+	"id":   true,
+	"ip":   true,
+	"json": true,
+	"uri":  true,
+	"url":  true,
+	"uuid": true,
+	"xml":  true,
+}
+
+// goSourceGenerator (English): State of GenerateGoSource(), the struct types in the
+// order they are declared and the packages used by them
+//
+// goSourceGenerator (Português): Estado de GenerateGoSource(), os tipos struct na ordem
+// em que são declarados e os pacotes usados por eles
+type goSourceGenerator struct {
+	declarationList []string
+	typeNameList    map[string]bool
+	importList      map[string]bool
+}
+
+// GenerateGoSource (English): Generates the gofmt-ed Go source of the model structs of
+// the schema.
+//
+// The root document is the struct typeName and each sub document is a struct named after
+// the property path, as in UserAddress for the property address of User. Optional fields
+// are pointers with omitempty, and title and description turn into doc comments. Values
+// of several bsonTypes, such as number, become interface{} with a comment that lists the
+// types.
+//
+//   var schema = MongoDBJsonSchema{}
+//   err = schema.UnmarshalJSON(data)
+//   ...
+//   source, err = schema.GenerateGoSource("model", "User")
+//
+// GenerateGoSource (Português): Gera o código Go, formatado pelo gofmt, das structs de
+// modelo do esquema.
+//
+// O documento raiz é a struct typeName e cada sub documento é uma struct nomeada a partir
+// do caminho da propriedade, como em UserAddress para a propriedade address de User.
+// Campos opcionais são ponteiros com omitempty, e title e description viram comentários
+// de documentação. Valores de vários bsonTypes, como number, viram interface{} com um
+// comentário que lista os tipos.
+//
+//   var schema = MongoDBJsonSchema{}
+//   err = schema.UnmarshalJSON(data)
+//   ...
+//   source, err = schema.GenerateGoSource("model", "User")
+func (el *MongoDBJsonSchema) GenerateGoSource(packageName, typeName string) (source []byte, err error) {
+	if el.source == nil {
+		err = errors.New("the schema must be populated before generate the source")
+		return
+	}
+
+	if typeName = el.goSourceName(typeName); typeName == "" {
+		err = errors.New("the type name must be a valid Go identifier")
+		return
+	}
+
+	var generator = goSourceGenerator{
+		declarationList: make([]string, 0),
+		typeNameList:    make(map[string]bool),
+		importList:      make(map[string]bool),
+	}
+
+	el.goSourceStruct(&generator, typeName, &el.TypeBsonObject)
+
+	var buffer bytes.Buffer
+	buffer.WriteString("// Code generated from a MongoDB $jsonSchema. DO NOT EDIT.\n\n")
+	buffer.WriteString("package " + packageName + "\n\n")
+
+	if len(generator.importList) != 0 {
+		var importList = make([]string, 0, len(generator.importList))
+		for path := range generator.importList {
+			importList = append(importList, path)
+		}
+		sort.Slice(importList, func(i, j int) bool {
+			var standardI = strings.Contains(importList[i], ".") == false
+			var standardJ = strings.Contains(importList[j], ".") == false
+			if standardI != standardJ {
+				return standardI
+			}
+
+			return importList[i] < importList[j]
+		})
+
+		buffer.WriteString("import (\n")
+		for k, path := range importList {
+			// (English): the packages of the standard library come first, as in goimports
+			//
+			// (Português): os pacotes da biblioteca padrão vêm primeiro, como no goimports
+			if k != 0 && strings.Contains(importList[k-1], ".") == false && strings.Contains(path, ".") == true {
+				buffer.WriteString("\n")
+			}
+
+			buffer.WriteString(strconv.Quote(path) + "\n")
+		}
+		buffer.WriteString(")\n\n")
+	}
+
+	for _, declaration := range generator.declarationList {
+		buffer.WriteString(declaration + "\n")
+	}
+
+	source, err = format.Source(buffer.Bytes())
+	return
+}
+
+// goSourceStruct (English): Declares the struct of a document and returns its name. The
+// struct is declared before its sub documents.
+//
+// goSourceStruct (Português): Declara a struct de um documento e retorna o seu nome. A
+// struct é declarada antes dos seus sub documentos.
+func (el *MongoDBJsonSchema) goSourceStruct(generator *goSourceGenerator, typeName string, rule *TypeBsonObject) string {
+	typeName = el.goSourceUniqueName(generator.typeNameList, typeName)
+
+	var index = len(generator.declarationList)
+	generator.declarationList = append(generator.declarationList, "")
+
+	var keyList = make([]string, 0, len(rule.Properties))
+	for key := range rule.Properties {
+		keyList = append(keyList, key)
+	}
+	sort.Strings(keyList)
+
+	var buffer bytes.Buffer
+	var title, description = rule.getMetadata()
+	buffer.WriteString(el.goSourceComment(typeName, title, description))
+	buffer.WriteString("type " + typeName + " struct {\n")
+
+	var fieldNameList = make(map[string]bool)
+	for _, key := range keyList {
+		var fieldName = el.goSourceUniqueName(fieldNameList, el.goSourceName(key))
+		if fieldName == "" {
+			fieldName = el.goSourceUniqueName(fieldNameList, "Field")
+		}
+
+		var goType, nullable = el.goSourceType(generator, typeName+fieldName, rule.Properties[key])
+
+		var omitEmpty = ""
+		if rule.Required[key] == false {
+			omitEmpty = ",omitempty"
+		}
+
+		if (nullable == true || omitEmpty != "") && el.goSourceIsNilable(goType) == false {
+			goType = "*" + goType
+		}
+
+		title, description = el.goSourceGetMetadata(rule.Properties[key])
+		if note := el.goSourceTypeNote(rule.Properties[key]); goType == "interface{}" && note != "" {
+			description = strings.TrimSpace(description + "\n\n" + note)
+		}
+
+		buffer.WriteString(el.goSourceComment(fieldName, title, description))
+		buffer.WriteString(fieldName + " " + goType + " `bson:" + strconv.Quote(key+omitEmpty) + " json:" + strconv.Quote(key+omitEmpty) + "`\n")
+	}
+
+	buffer.WriteString("}\n")
+	generator.declarationList[index] = buffer.String()
+	return typeName
+}
+
+// goSourceType (English): Returns the Go type of the list of alternatives of one value.
+// nullable is true when null is one of the alternatives.
+//
+// goSourceType (Português): Retorna o tipo Go da lista de alternativas de um valor.
+// nullable é true quando null é uma das alternativas.
+func (el *MongoDBJsonSchema) goSourceType(generator *goSourceGenerator, typeName string, alternatives map[string]BsonType) (goType string, nullable bool) {
+	var typeList = make([]string, 0, len(alternatives))
+	for typeString := range alternatives {
+		if typeString == "null" {
+			nullable = true
+			continue
+		}
+
+		typeList = append(typeList, typeString)
+	}
+	sort.Strings(typeList)
+
+	switch strings.Join(typeList, ",") {
+	case "":
+		goType = "interface{}"
+		return
+	case "int,long":
+		goType = "int64"
+		return
+	case "double,int", "double,long", "double,int,long":
+		goType = "float64"
+		return
+	}
+
+	if len(typeList) != 1 {
+		goType = "interface{}"
+		return
+	}
+
+	switch rule := alternatives[typeList[0]].ElementType.(type) {
+	case *TypeBsonObject:
+		if len(rule.Properties) != 0 {
+			goType = el.goSourceStruct(generator, typeName, rule)
+			return
+		}
+
+		if rule.AdditionalPropertiesMap != nil {
+			var valueType, valueNullable = el.goSourceType(generator, typeName+"Value", rule.AdditionalPropertiesMap)
+			if valueNullable == true && el.goSourceIsNilable(valueType) == false {
+				valueType = "*" + valueType
+			}
+
+			goType = "map[string]" + valueType
+			return
+		}
+
+		goType = "map[string]interface{}"
+
+	case *TypeBsonGeneric:
+		// (English): a schema without bsonType that declares properties describes a document
+		//
+		// (Português): um esquema sem bsonType que declara properties descreve um documento
+		if rule.Object != nil && len(rule.Object.Properties) != 0 {
+			goType = el.goSourceStruct(generator, typeName, rule.Object)
+			nullable = true
+			return
+		}
+
+		goType = "interface{}"
+
+	case *TypeBsonArray:
+		if rule.Items == nil || rule.ItemsTuple != nil {
+			goType = "[]interface{}"
+			return
+		}
+
+		var itemType, itemNullable = el.goSourceType(generator, typeName, rule.Items)
+		if itemNullable == true && el.goSourceIsNilable(itemType) == false {
+			itemType = "*" + itemType
+		}
+
+		goType = "[]" + itemType
+
+	default:
+		var found bool
+		goType, found = goSourceScalarTypeList[typeList[0]]
+		if found == false {
+			goType = "interface{}"
+		}
+	}
+
+	if strings.Contains(goType, "primitive.") == true {
+		generator.importList["go.mongodb.org/mongo-driver/bson/primitive"] = true
+	}
+
+	if strings.Contains(goType, "time.") == true {
+		generator.importList["time"] = true
+	}
+
+	return
+}
+
+// goSourceTypeNote (English): Describes the alternatives of a value that turns into
+// interface{}, as the Go source has no other type information for it. Numbers with
+// decimal are not narrowed to float64 because the mongo driver does not decode decimal
+// into float64.
+//
+// goSourceTypeNote (Português): Descreve as alternativas de um valor que vira
+// interface{}, pois o código Go não tem outra informação de tipo para ele. Números com
+// decimal não são reduzidos para float64 porque o driver do mongo não decodifica decimal
+// em float64.
+func (el *MongoDBJsonSchema) goSourceTypeNote(alternatives map[string]BsonType) string {
+	var typeList = make([]string, 0, len(alternatives))
+	var onlyNumbers = true
+	for typeString := range alternatives {
+		if typeString == "null" || typeString == "generic" {
+			continue
+		}
+
+		switch typeString {
+		case "int", "long", "double", "decimal":
+		default:
+			onlyNumbers = false
+		}
+
+		typeList = append(typeList, typeString)
+	}
+	sort.Strings(typeList)
+
+	if len(typeList) < 2 {
+		return ""
+	}
+
+	var note = "bsonType " + strings.Join(typeList[:len(typeList)-1], ", ") + " or " + typeList[len(typeList)-1]
+	if _, found := alternatives["decimal"]; found == true && onlyNumbers == true {
+		note += ", kept as interface{} because float64 does not decode decimal"
+	}
+
+	return note
+}
+
+// goSourceGetMetadata (English): Returns the title and the description of the first
+// alternative that has them
+//
+// goSourceGetMetadata (Português): Retorna o título e a descrição da primeira
+// alternativa que os tem
+func (el *MongoDBJsonSchema) goSourceGetMetadata(alternatives map[string]BsonType) (title, description string) {
+	var typeList = make([]string, 0, len(alternatives))
+	for typeString := range alternatives {
+		typeList = append(typeList, typeString)
+	}
+	sort.Strings(typeList)
+
+	for _, typeString := range typeList {
+		metadata, ok := alternatives[typeString].ElementType.(interfaceBsonMetadata)
+		if ok == false {
+			continue
+		}
+
+		title, description = metadata.getMetadata()
+		if title != "" || description != "" {
+			return
+		}
+	}
+
+	return
+}
+
+// goSourceComment (English): Turns title and description into the doc comment of name
+//
+// goSourceComment (Português): Transforma title e description no comentário de
+// documentação de name
+func (el *MongoDBJsonSchema) goSourceComment(name, title, description string) string {
+	if title == "" && description == "" {
+		return ""
+	}
+
+	var lineList = make([]string, 0)
+	if title != "" {
+		lineList = append(lineList, name+": "+strings.TrimSpace(title))
+	}
+
+	if description != "" {
+		if title != "" {
+			lineList = append(lineList, "")
+		} else {
+			description = name + ": " + description
+		}
+
+		lineList = append(lineList, strings.Split(strings.TrimSpace(description), "\n")...)
+	}
+
+	var buffer bytes.Buffer
+	for _, line := range lineList {
+		buffer.WriteString(strings.TrimRight("// "+line, " ") + "\n")
+	}
+
+	return buffer.String()
+}
+
+// goSourceName (English): Turns a key of the document into an exported Go name, as in
+// _id into ID and created_at into CreatedAt
+//
+// goSourceName (Português): Transforma uma chave do documento em um nome Go exportado,
+// como em _id em ID e created_at em CreatedAt
+func (el *MongoDBJsonSchema) goSourceName(key string) (name string) {
+	var partList = strings.FieldsFunc(key, func(r rune) bool {
+		return unicode.IsLetter(r) == false && unicode.IsDigit(r) == false
+	})
+
+	for _, part := range partList {
+		if goSourceInitialismList[strings.ToLower(part)] == true {
+			name += strings.ToUpper(part)
+			continue
+		}
+
+		var runeList = []rune(part)
+		runeList[0] = unicode.ToUpper(runeList[0])
+		name += string(runeList)
+	}
+
+	if name != "" && unicode.IsLetter([]rune(name)[0]) == false {
+		name = "Field" + name
+	}
+
+	return
+}
+
+// goSourceUniqueName (English): Returns name, or name followed by a number when name is
+// already in use, and marks it as used
+//
+// goSourceUniqueName (Português): Retorna name, ou name seguido de um número quando name
+// já está em uso, e o marca como usado
+func (el *MongoDBJsonSchema) goSourceUniqueName(nameList map[string]bool, name string) string {
+	if name == "" {
+		return ""
+	}
+
+	var unique = name
+	for counter := 2; nameList[unique] == true; counter++ {
+		unique = name + strconv.Itoa(counter)
+	}
+
+	nameList[unique] = true
+	return unique
+}
+
+// goSourceIsNilable (English): Returns true for the Go types that already have nil as
+// zero value
+//
+// goSourceIsNilable (Português): Retorna true para os tipos Go que já têm nil como valor
+// zero
+func (el *MongoDBJsonSchema) goSourceIsNilable(goType string) bool {
+	return strings.HasPrefix(goType, "[]") == true ||
+		strings.HasPrefix(goType, "map[") == true ||
+		strings.HasPrefix(goType, "*") == true ||
+		goType == "interface{}"
+}
@@ -0,0 +1,124 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const generateGoSourceTestSchema = `
+{
+  "$jsonSchema": {
+    "bsonType": "object",
+    "title": "User of the system",
+    "description": "One document for each login",
+    "required": ["_id", "name", "address", "nick"],
+    "properties": {
+      "_id": { "bsonType": "objectId" },
+      "name": { "bsonType": "string", "title": "Full name" },
+      "nick": { "bsonType": ["string", "null"] },
+      "age": { "bsonType": "int" },
+      "score": { "bsonType": ["int", "long"] },
+      "created_at": { "bsonType": "date", "description": "creation date" },
+      "tags": { "bsonType": "array", "items": { "bsonType": "string" } },
+      "friends": {
+        "bsonType": "array",
+        "items": { "bsonType": "object", "properties": { "name": { "bsonType": "string" } } }
+      },
+      "address": {
+        "bsonType": "object",
+        "required": ["street"],
+        "properties": {
+          "street": { "bsonType": "string" },
+          "geo": { "bsonType": "object", "properties": { "lat": { "bsonType": "double" } } }
+        }
+      },
+      "extra": { "bsonType": "object", "additionalProperties": { "bsonType": "decimal" } },
+      "any": {},
+      "amount": { "bsonType": "number" },
+      "code": { "bsonType": ["int", "string"], "description": "old or new code" },
+      "meta": { "properties": { "source": { "bsonType": "string" } } }
+    }
+  }
+}
+`
+
+func TestMongoDBJsonSchema_GenerateGoSource(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(generateGoSourceTestSchema))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var source []byte
+	source, err = schema.GenerateGoSource("model", "user")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	// (English): gofmt aligns the fields, the test compares with single spaces
+	//
+	// (Português): o gofmt alinha os campos, o teste compara com espaços simples
+	var text = regexp.MustCompile(`[ \t]+`).ReplaceAllString(string(source), " ")
+
+	var expectedList = []string{
+		"// Code generated from a MongoDB $jsonSchema. DO NOT EDIT.\n",
+		"package model\n",
+		"import (\n \"time\"\n\n \"go.mongodb.org/mongo-driver/bson/primitive\"\n)\n",
+		"// User: User of the system\n//\n// One document for each login\ntype User struct {\n",
+		" ID primitive.ObjectID `bson:\"_id\" json:\"_id\"`\n",
+		" // Name: Full name\n Name string `bson:\"name\" json:\"name\"`\n",
+		" Nick *string `bson:\"nick\" json:\"nick\"`\n",
+		" Age *int32 `bson:\"age,omitempty\" json:\"age,omitempty\"`\n",
+		" Score *int64 `bson:\"score,omitempty\" json:\"score,omitempty\"`\n",
+		" // CreatedAt: creation date\n CreatedAt *time.Time `bson:\"created_at,omitempty\" json:\"created_at,omitempty\"`\n",
+		" Tags []string `bson:\"tags,omitempty\" json:\"tags,omitempty\"`\n",
+		" Friends []UserFriends `bson:\"friends,omitempty\" json:\"friends,omitempty\"`\n",
+		" Address UserAddress `bson:\"address\" json:\"address\"`\n",
+		" Extra map[string]primitive.Decimal128 `bson:\"extra,omitempty\" json:\"extra,omitempty\"`\n",
+		" Any interface{} `bson:\"any,omitempty\" json:\"any,omitempty\"`\n",
+		" // Amount: bsonType decimal, double, int or long, kept as interface{} because float64 does not decode decimal\n Amount interface{} `bson:\"amount,omitempty\" json:\"amount,omitempty\"`\n",
+		" // Code: old or new code\n //\n // bsonType int or string\n Code interface{} `bson:\"code,omitempty\" json:\"code,omitempty\"`\n",
+		" Meta *UserMeta `bson:\"meta,omitempty\" json:\"meta,omitempty\"`\n",
+		"type UserMeta struct {\n Source *string `bson:\"source,omitempty\" json:\"source,omitempty\"`\n}\n",
+		"type UserAddress struct {\n Geo *UserAddressGeo `bson:\"geo,omitempty\" json:\"geo,omitempty\"`\n Street string `bson:\"street\" json:\"street\"`\n}\n",
+		"type UserAddressGeo struct {\n Lat *float64 `bson:\"lat,omitempty\" json:\"lat,omitempty\"`\n}\n",
+		"type UserFriends struct {\n Name *string `bson:\"name,omitempty\" json:\"name,omitempty\"`\n}\n",
+	}
+
+	for _, expected := range expectedList {
+		if strings.Contains(text, expected) == false {
+			t.Errorf("%q not found in:\n%s", expected, source)
+		}
+	}
+
+	if strings.Index(text, "type User struct") > strings.Index(text, "type UserAddress struct") {
+		t.Errorf("the root type must be declared first:\n%s", source)
+	}
+}
+
+func TestMongoDBJsonSchema_GenerateGoSourceName(t *testing.T) {
+	var schema = MongoDBJsonSchema{}
+	var tests = []struct {
+		key  string
+		name string
+	}{
+		{"_id", "ID"},
+		{"created_at", "CreatedAt"},
+		{"createdAt", "CreatedAt"},
+		{"user-url", "UserURL"},
+		{"2fa", "Field2fa"},
+		{"$", ""},
+	}
+
+	for _, test := range tests {
+		if name := schema.goSourceName(test.key); name != test.name {
+			t.Errorf("%v: expected %v, got %v", test.key, test.name, name)
+		}
+	}
+
+	if _, err := schema.GenerateGoSource("model", "User"); err == nil {
+		t.Errorf("an empty schema must return an error")
+	}
+}
@@ -0,0 +1,536 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// structTagKeywordList (English): Keywords of the schema that can be defined by struct
+// tags with the same name, as in
+//
+//   Name string `bson:"name" maxLength:"10" pattern:"^[A-Z]"`
+//   Status string `bson:"status" enum:"on,off"`
+//
+// structTagKeywordList (Português): Palavras chave do esquema que podem ser definidas
+// por tags da struct com o mesmo nome, como em
+//
+//   Name string `bson:"name" maxLength:"10" pattern:"^[A-Z]"`
+//   Status string `bson:"status" enum:"on,off"`
+var structTagKeywordList = []string{
+	"title",
+	"description",
+	"enum",
+	"multipleOf",
+	"maximum",
+	"exclusiveMaximum",
+	"minimum",
+	"exclusiveMinimum",
+	"maxLength",
+	"minLength",
+	"pattern",
+	"maxItems",
+	"minItems",
+	"uniqueItems",
+	"maxProperties",
+	"minProperties",
+}
+
+// structBsonTypeList (English): bsonType of the struct types of the mongo driver and of
+// the standard library that are not documents
+//
+// structBsonTypeList (Português): bsonType dos tipos do driver do mongo e da biblioteca
+// padrão que não são documentos
+var structBsonTypeList = map[reflect.Type]string{
+	reflect.TypeOf(time.Time{}):               "date",
+	reflect.TypeOf(primitive.DateTime(0)):     "date",
+	reflect.TypeOf(primitive.ObjectID{}):      "objectId",
+	reflect.TypeOf(primitive.Timestamp{}):     "timestamp",
+	reflect.TypeOf(primitive.Decimal128{}):    "decimal",
+	reflect.TypeOf(primitive.Binary{}):        "binData",
+	reflect.TypeOf(primitive.Regex{}):         "regex",
+	reflect.TypeOf(primitive.JavaScript("")):  "javascript",
+	reflect.TypeOf(primitive.Symbol("")):      "symbol",
+	reflect.TypeOf(primitive.CodeWithScope{}): "javascriptWithScope",
+	reflect.TypeOf(primitive.DBPointer{}):     "dbPointer",
+	reflect.TypeOf(primitive.MinKey{}):        "minKey",
+	reflect.TypeOf(primitive.MaxKey{}):        "maxKey",
+	reflect.TypeOf(primitive.Undefined{}):     "undefined",
+	reflect.TypeOf(primitive.Null{}):          "null",
+	reflect.TypeOf(primitive.D{}):             "object",
+	reflect.TypeOf(primitive.M{}):             "object",
+	reflect.TypeOf(bson.Raw{}):                "object",
+	reflect.TypeOf(primitive.A{}):             "array",
+}
+
+// PopulateFromStruct (English): Creates the schema from a Go struct, or a pointer to a
+// struct, the same way the mongo driver marshals it.
+//
+// The key names come from the bson tags; fields without omitempty are required; pointers,
+// slices and maps without omitempty also accept null. Other keywords are read from struct
+// tags with the same name of the keyword:
+//
+//   type User struct {
+//     ID     primitive.ObjectID `bson:"_id,omitempty"`
+//     Name   string             `bson:"name" maxLength:"10" pattern:"^[A-Z]"`
+//     Status string             `bson:"status,omitempty" enum:"on,off"`
+//     Age    int32              `bson:"age" minimum:"18" maximum:"99"`
+//   }
+//
+//   var schema = MongoDBJsonSchema{}
+//   err = schema.PopulateFromStruct(User{})
+//   ...
+//   data, err = schema.JSONSchema()
+//
+// PopulateFromStruct (Português): Cria o esquema a partir de uma struct Go, ou de um
+// ponteiro para uma struct, da mesma forma que o driver do mongo a converte.
+//
+// Os nomes das chaves vêm das tags bson; campos sem omitempty são requeridos;
+// ponteiros, slices e mapas sem omitempty também aceitam null. As demais palavras chave
+// são lidas de tags da struct com o mesmo nome da palavra chave:
+//
+//   type User struct {
+//     ID     primitive.ObjectID `bson:"_id,omitempty"`
+//     Name   string             `bson:"name" maxLength:"10" pattern:"^[A-Z]"`
+//     Status string             `bson:"status,omitempty" enum:"on,off"`
+//     Age    int32              `bson:"age" minimum:"18" maximum:"99"`
+//   }
+//
+//   var schema = MongoDBJsonSchema{}
+//   err = schema.PopulateFromStruct(User{})
+//   ...
+//   data, err = schema.JSONSchema()
+func (el *MongoDBJsonSchema) PopulateFromStruct(value interface{}) (err error) {
+	var structType = reflect.TypeOf(value)
+	for structType != nil && structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if structType == nil || structType.Kind() != reflect.Struct {
+		err = errors.New("the value must be a struct or a pointer to a struct")
+		return
+	}
+
+	var schema map[string]interface{}
+	schema, err = el.structToSchema(structType, make(map[reflect.Type]bool))
+	if err != nil {
+		return
+	}
+
+	err = el.Populate(schema)
+	return
+}
+
+// JSONSchema (English): Returns the json of the populated schema, as in
+// {"$jsonSchema": {...}}, ready to be used as validator of collMod or createCollection
+//
+// JSONSchema (Português): Retorna o json do esquema populado, como em
+// {"$jsonSchema": {...}}, pronto para ser usado como validator de collMod ou
+// createCollection
+func (el *MongoDBJsonSchema) JSONSchema() (data []byte, err error) {
+	if el.source == nil {
+		err = errors.New("the schema must be populated before marshal")
+		return
+	}
+
+	data, err = json.Marshal(map[string]interface{}{"$jsonSchema": el.source})
+	return
+}
+
+// structToSchema (English): Creates the schema of a struct type. visiting has the struct
+// types in use, to stop recursive types.
+//
+// structToSchema (Português): Cria o esquema de um tipo struct. visiting tem os tipos
+// struct em uso, para parar tipos recursivos.
+func (el *MongoDBJsonSchema) structToSchema(structType reflect.Type, visiting map[reflect.Type]bool) (schema map[string]interface{}, err error) {
+	schema = map[string]interface{}{"bsonType": "object"}
+	if visiting[structType] == true {
+		return
+	}
+
+	visiting[structType] = true
+	defer delete(visiting, structType)
+
+	var properties = make(map[string]interface{})
+	var required = make([]interface{}, 0)
+	err = el.structFieldsToSchema(structType, visiting, schema, properties, &required)
+	if err != nil {
+		return
+	}
+
+	schema["properties"] = properties
+	if len(required) != 0 {
+		schema["required"] = required
+	}
+
+	return
+}
+
+// structFieldsToSchema (English): Adds the fields of the struct to properties and
+// required. Inline structs add their fields to the same document.
+//
+// structFieldsToSchema (Português): Adiciona os campos da struct em properties e
+// required. Structs inline adicionam os seus campos ao mesmo documento.
+func (el *MongoDBJsonSchema) structFieldsToSchema(structType reflect.Type, visiting map[reflect.Type]bool, schema, properties map[string]interface{}, required *[]interface{}) (err error) {
+	for i := 0; i < structType.NumField(); i++ {
+		var field = structType.Field(i)
+
+		// (English): the mongo driver ignores unexported fields
+		//
+		// (Português): o driver do mongo ignora campos não exportados
+		if field.PkgPath != "" {
+			continue
+		}
+
+		var tags bsoncodec.StructTags
+		tags, err = bsoncodec.DefaultStructTagParser(field)
+		if err != nil {
+			return
+		}
+
+		if tags.Skip == true {
+			continue
+		}
+
+		if tags.Inline == true {
+			err = el.structInlineToSchema(structType, field, visiting, schema, properties, required)
+			if err != nil {
+				return
+			}
+			continue
+		}
+
+		if _, found := properties[tags.Name]; found == true {
+			err = errors.New(structType.String() + ": duplicated key " + tags.Name)
+			return
+		}
+
+		var fieldSchema map[string]interface{}
+		var nullable bool
+		fieldSchema, nullable, err = el.typeToSchema(field.Type, tags, visiting)
+		if err != nil {
+			err = errors.New(structType.String() + "." + field.Name + ": " + err.Error())
+			return
+		}
+
+		if nullable == true && tags.OmitEmpty == false {
+			el.appendNullToSchema(fieldSchema)
+		}
+
+		err = el.structTagsToSchema(field, fieldSchema)
+		if err != nil {
+			err = errors.New(structType.String() + "." + field.Name + ": " + err.Error())
+			return
+		}
+
+		properties[tags.Name] = fieldSchema
+		if tags.OmitEmpty == false {
+			*required = append(*required, tags.Name)
+		}
+	}
+
+	return
+}
+
+// structInlineToSchema (English): Adds the fields of an inline struct to the document.
+// An inline map allows additional fields with the schema of the map values.
+//
+// structInlineToSchema (Português): Adiciona os campos de uma struct inline ao
+// documento. Um mapa inline permite campos adicionais com o esquema dos valores do mapa.
+func (el *MongoDBJsonSchema) structInlineToSchema(structType reflect.Type, field reflect.StructField, visiting map[reflect.Type]bool, schema, properties map[string]interface{}, required *[]interface{}) (err error) {
+	var fieldType = field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Struct:
+		err = el.structFieldsToSchema(fieldType, visiting, schema, properties, required)
+
+	case reflect.Map:
+		if field.Type.Kind() == reflect.Ptr || fieldType.Key().Kind() != reflect.String {
+			err = errors.New(structType.String() + "." + field.Name + ": inline map must have string keys")
+			return
+		}
+
+		var valueSchema map[string]interface{}
+		valueSchema, _, err = el.typeToSchema(fieldType.Elem(), bsoncodec.StructTags{}, visiting)
+		if err != nil {
+			return
+		}
+
+		if len(valueSchema) != 0 {
+			schema["additionalProperties"] = valueSchema
+		}
+
+	default:
+		err = errors.New(structType.String() + "." + field.Name + ": inline fields must be a struct, a struct pointer, or a map")
+	}
+
+	return
+}
+
+// typeToSchema (English): Creates the schema of a Go type. nullable is true for the types
+// marshaled as null when they are nil.
+//
+// typeToSchema (Português): Cria o esquema de um tipo Go. nullable é true para os tipos
+// convertidos em null quando são nil.
+func (el *MongoDBJsonSchema) typeToSchema(goType reflect.Type, tags bsoncodec.StructTags, visiting map[reflect.Type]bool) (schema map[string]interface{}, nullable bool, err error) {
+	schema = make(map[string]interface{})
+
+	if bsonType, found := structBsonTypeList[goType]; found == true {
+		schema["bsonType"] = bsonType
+		nullable = goType.Kind() == reflect.Slice || goType.Kind() == reflect.Map
+		return
+	}
+
+	// (English): the bsonType of types with their own marshaler is unknown, except for
+	// bson.Marshaler, which always returns a document
+	//
+	// (Português): o bsonType de tipos com o seu próprio conversor é desconhecido, exceto
+	// para bson.Marshaler, que sempre retorna um documento
+	if goType.Implements(reflect.TypeOf((*bson.Marshaler)(nil)).Elem()) == true {
+		schema["bsonType"] = "object"
+		nullable = goType.Kind() == reflect.Ptr
+		return
+	}
+
+	if goType.Implements(reflect.TypeOf((*bson.ValueMarshaler)(nil)).Elem()) == true {
+		return
+	}
+
+	switch goType.Kind() {
+	case reflect.Bool:
+		schema["bsonType"] = "bool"
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		schema["bsonType"] = "int"
+
+	case reflect.Int:
+		schema["bsonType"] = []interface{}{"int", "long"}
+
+	case reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		schema["bsonType"] = "long"
+		if tags.MinSize == true {
+			schema["bsonType"] = []interface{}{"int", "long"}
+		}
+
+	case reflect.Float32, reflect.Float64:
+		schema["bsonType"] = "double"
+
+	case reflect.String:
+		schema["bsonType"] = "string"
+
+	case reflect.Interface:
+		nullable = true
+
+	case reflect.Ptr:
+		schema, _, err = el.typeToSchema(goType.Elem(), tags, visiting)
+		nullable = true
+
+	case reflect.Slice, reflect.Array:
+		nullable = goType.Kind() == reflect.Slice
+		if goType.Elem().Kind() == reflect.Uint8 {
+			schema["bsonType"] = "binData"
+			return
+		}
+
+		var itemSchema map[string]interface{}
+		var itemNullable bool
+		itemSchema, itemNullable, err = el.typeToSchema(goType.Elem(), bsoncodec.StructTags{}, visiting)
+		if err != nil {
+			return
+		}
+
+		if itemNullable == true {
+			el.appendNullToSchema(itemSchema)
+		}
+
+		schema["bsonType"] = "array"
+		schema["items"] = itemSchema
+
+	case reflect.Map:
+		nullable = true
+		if goType.Key().Kind() != reflect.String {
+			err = errors.New("unsupported type " + goType.String() + ", the map keys must be strings")
+			return
+		}
+
+		var valueSchema map[string]interface{}
+		var valueNullable bool
+		valueSchema, valueNullable, err = el.typeToSchema(goType.Elem(), bsoncodec.StructTags{}, visiting)
+		if err != nil {
+			return
+		}
+
+		if valueNullable == true {
+			el.appendNullToSchema(valueSchema)
+		}
+
+		schema["bsonType"] = "object"
+		if len(valueSchema) != 0 {
+			schema["additionalProperties"] = valueSchema
+		}
+
+	case reflect.Struct:
+		schema, err = el.structToSchema(goType, visiting)
+
+	default:
+		err = errors.New("unsupported type " + goType.String())
+	}
+
+	return
+}
+
+// appendNullToSchema (English): Adds the type null to the bsonType of the schema. A
+// schema without bsonType already accepts null.
+//
+// appendNullToSchema (Português): Adiciona o tipo null ao bsonType do esquema. Um esquema
+// sem bsonType já aceita null.
+func (el *MongoDBJsonSchema) appendNullToSchema(schema map[string]interface{}) {
+	switch bsonType := schema["bsonType"].(type) {
+	case string:
+		if bsonType != "null" {
+			schema["bsonType"] = []interface{}{bsonType, "null"}
+		}
+	case []interface{}:
+		schema["bsonType"] = append(bsonType, "null")
+	}
+}
+
+// structTagsToSchema (English): Reads the keywords defined by struct tags, see
+// structTagKeywordList
+//
+// structTagsToSchema (Português): Lê as palavras chave definidas por tags da struct, veja
+// structTagKeywordList
+func (el *MongoDBJsonSchema) structTagsToSchema(field reflect.StructField, schema map[string]interface{}) (err error) {
+	for _, keyword := range structTagKeywordList {
+		text, found := field.Tag.Lookup(keyword)
+		if found == false {
+			continue
+		}
+
+		switch keyword {
+		case "title", "description", "pattern":
+			schema[keyword] = text
+
+		case "exclusiveMaximum", "exclusiveMinimum", "uniqueItems":
+			schema[keyword], err = strconv.ParseBool(text)
+
+		case "maxLength", "minLength", "maxItems", "minItems", "maxProperties", "minProperties":
+			schema[keyword], err = strconv.ParseInt(text, 10, 64)
+
+		case "multipleOf", "maximum", "minimum":
+			schema[keyword], err = el.structTagToNumber(schema, keyword, text)
+
+		case "enum":
+			var enum = make([]interface{}, 0)
+			for _, item := range strings.Split(text, ",") {
+				var converted interface{}
+				converted, err = el.structTagToValue(schema, item)
+				if err != nil {
+					break
+				}
+
+				enum = append(enum, converted)
+			}
+			schema[keyword] = enum
+		}
+
+		if err != nil {
+			err = errors.New(keyword + ": " + err.Error())
+			return
+		}
+	}
+
+	return
+}
+
+// structTagToNumber (English): Converts the text of the multipleOf, maximum or minimum
+// tags into a number of the first bsonType of the schema, as MongoDB only accepts numbers
+// in these keywords. Decimal bounds become float64 rounded outward, a field without
+// bsonType takes a float64 and the other types, such as date, return an error
+//
+// structTagToNumber (Português): Converte o texto das tags multipleOf, maximum ou minimum
+// em um número do primeiro bsonType do esquema, pois o MongoDB só aceita números nestas
+// palavras chave. Limites decimais viram float64 arredondado para fora, um campo sem
+// bsonType recebe um float64 e os outros tipos, como date, retornam um erro
+func (el *MongoDBJsonSchema) structTagToNumber(schema map[string]interface{}, keyword, text string) (value interface{}, err error) {
+	var bsonType, _ = schema["bsonType"].(string)
+	if list, isList := schema["bsonType"].([]interface{}); isList == true && len(list) != 0 {
+		bsonType, _ = list[0].(string)
+	}
+
+	switch bsonType {
+	case "int", "long", "double":
+		value, err = el.structTagToValue(schema, text)
+
+	case "":
+		value, err = strconv.ParseFloat(text, 64)
+
+	case "decimal":
+		if decimalTextRegexp.MatchString(text) == false {
+			err = errors.New("invalid decimal " + text)
+			return
+		}
+
+		var number, ok = new(big.Rat).SetString(text)
+
+		var converted float64
+		switch keyword {
+		case "maximum":
+			converted, ok = ratToFloat64Bound(number, 1)
+		case "minimum":
+			converted, ok = ratToFloat64Bound(number, -1)
+		default:
+			converted, _ = number.Float64()
+			ok = math.IsInf(converted, 0) == false
+		}
+
+		if ok == false {
+			err = errors.New(text + " is out of the float64 range")
+			return
+		}
+
+		value = converted
+
+	default:
+		err = errors.New("does not apply to bsonType " + bsonType)
+	}
+
+	return
+}
+
+// structTagToValue (English): Converts the text of a struct tag into a value of the
+// first bsonType of the schema
+//
+// structTagToValue (Português): Converte o texto de uma tag da struct em um valor do
+// primeiro bsonType do esquema
+func (el *MongoDBJsonSchema) structTagToValue(schema map[string]interface{}, text string) (value interface{}, err error) {
+	var bsonType, _ = schema["bsonType"].(string)
+	if list, isList := schema["bsonType"].([]interface{}); isList == true && len(list) != 0 {
+		bsonType, _ = list[0].(string)
+	}
+
+	switch bsonType {
+	case "int", "long":
+		value, err = strconv.ParseInt(text, 10, 64)
+	case "double":
+		value, err = strconv.ParseFloat(text, 64)
+	case "bool":
+		value, err = strconv.ParseBool(text)
+	default:
+		value = text
+	}
+
+	return
+}
@@ -0,0 +1,273 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PopulateFromStructTestAudit struct {
+	CreatedAt time.Time  `bson:"createdAt"`
+	DeletedAt *time.Time `bson:"deletedAt,omitempty"`
+}
+
+type populateFromStructTestAddress struct {
+	Street string `bson:"street" minLength:"3"`
+	Number int32  `bson:"number" minimum:"1"`
+}
+
+type populateFromStructTestNode struct {
+	Name     string                        `bson:"name"`
+	Children []*populateFromStructTestNode `bson:"children,omitempty"`
+}
+
+type populateFromStructTestUser struct {
+	ID                          primitive.ObjectID             `bson:"_id,omitempty"`
+	Name                        string                         `bson:"name" maxLength:"10" pattern:"^[A-Z]" description:"user name"`
+	Status                      string                         `bson:"status,omitempty" enum:"on,off"`
+	Level                       int64                          `bson:"level" enum:"1,2,3"`
+	Score                       float64                        `bson:"score,omitempty" minimum:"0" exclusiveMinimum:"true"`
+	Active                      bool                           `bson:"active"`
+	Count                       int                            `bson:"count"`
+	Balance                     primitive.Decimal128           `bson:"balance,omitempty" minimum:"0.01"`
+	Photo                       []byte                         `bson:"photo,omitempty"`
+	Tags                        []string                       `bson:"tags" uniqueItems:"true" maxItems:"3"`
+	Address                     *populateFromStructTestAddress `bson:"address,omitempty"`
+	Extra                       map[string]int32               `bson:"extra,omitempty"`
+	Any                         interface{}                    `bson:"any,omitempty"`
+	Ignored                     string                         `bson:"-"`
+	LowerCase                   string                         `bson:",omitempty"`
+	PopulateFromStructTestAudit `bson:",inline"`
+	private                     string
+}
+
+func populateFromStructTestGetSource(t *testing.T, schema *MongoDBJsonSchema) (source map[string]interface{}) {
+	data, err := schema.JSONSchema()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	source, _ = decoded["$jsonSchema"].(map[string]interface{})
+	return
+}
+
+func TestMongoDBJsonSchema_PopulateFromStruct(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.PopulateFromStruct(&populateFromStructTestUser{})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var source = populateFromStructTestGetSource(t, &schema)
+	if source["bsonType"] != "object" {
+		t.Errorf("unexpected bsonType: %v", source["bsonType"])
+	}
+
+	var required = []interface{}{"name", "level", "active", "count", "tags", "createdAt"}
+	if reflect.DeepEqual(source["required"], required) == false {
+		t.Errorf("expected required %v, got %v", required, source["required"])
+	}
+
+	var properties, _ = source["properties"].(map[string]interface{})
+	var tests = []struct {
+		key      string
+		bsonType interface{}
+	}{
+		{"_id", "objectId"},
+		{"name", "string"},
+		{"status", "string"},
+		{"level", "long"},
+		{"score", "double"},
+		{"active", "bool"},
+		{"count", []interface{}{"int", "long"}},
+		{"balance", "decimal"},
+		{"photo", "binData"},
+		{"tags", []interface{}{"array", "null"}},
+		{"address", "object"},
+		{"extra", "object"},
+		{"any", nil},
+		{"lowercase", "string"},
+		{"createdAt", "date"},
+		{"deletedAt", "date"},
+	}
+
+	if len(properties) != len(tests) {
+		t.Errorf("expected %v properties, got %v", len(tests), properties)
+	}
+
+	for _, test := range tests {
+		property, found := properties[test.key].(map[string]interface{})
+		if found == false {
+			t.Errorf("%v: property not found", test.key)
+			continue
+		}
+
+		if reflect.DeepEqual(property["bsonType"], test.bsonType) == false {
+			t.Errorf("%v: expected bsonType %v, got %v", test.key, test.bsonType, property["bsonType"])
+		}
+	}
+
+	var name, _ = properties["name"].(map[string]interface{})
+	if name["maxLength"] != float64(10) || name["pattern"] != "^[A-Z]" || name["description"] != "user name" {
+		t.Errorf("unexpected name: %v", name)
+	}
+
+	var level, _ = properties["level"].(map[string]interface{})
+	if reflect.DeepEqual(level["enum"], []interface{}{float64(1), float64(2), float64(3)}) == false {
+		t.Errorf("unexpected level: %v", level)
+	}
+
+	var balance, _ = properties["balance"].(map[string]interface{})
+	if balance["minimum"] != 0.01 {
+		t.Errorf("expected numeric minimum, got %v", balance)
+	}
+
+	var address, _ = properties["address"].(map[string]interface{})
+	if reflect.DeepEqual(address["required"], []interface{}{"street", "number"}) == false {
+		t.Errorf("unexpected address: %v", address)
+	}
+
+	var extra, _ = properties["extra"].(map[string]interface{})
+	if reflect.DeepEqual(extra["additionalProperties"], map[string]interface{}{"bsonType": "int"}) == false {
+		t.Errorf("unexpected extra: %v", extra)
+	}
+}
+
+func TestMongoDBJsonSchema_PopulateFromStructValidate(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.PopulateFromStruct(populateFromStructTestUser{})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var balance, _ = primitive.ParseDecimal128("0.01")
+	var lowBalance, _ = primitive.ParseDecimal128("0.001")
+	var valid = populateFromStructTestUser{
+		ID:      primitive.NewObjectID(),
+		Name:    "Dino",
+		Status:  "on",
+		Level:   2,
+		Count:   1 << 40,
+		Balance: balance,
+		Tags:    []string{"a", "b"},
+		Address: &populateFromStructTestAddress{Street: "Main", Number: 1},
+		Extra:   map[string]int32{"a": 1},
+	}
+	valid.CreatedAt = time.Now()
+
+	var result = schema.Validate(valid)
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	var invalid = valid
+	invalid.Name = "dino sauro rex"
+	invalid.Status = "standby"
+	invalid.Level = 4
+	invalid.Score = -1
+	invalid.Balance = lowBalance
+	invalid.Tags = []string{"a", "a"}
+	invalid.Address = &populateFromStructTestAddress{}
+
+	var expected = []string{"name", "status", "level", "score", "balance", "tags", "address.street", "address.number"}
+	result = schema.Validate(invalid)
+	if len(result.Violations) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Errors())
+	}
+
+	for k := range expected {
+		if result.Violations[k].Path != expected[k] {
+			t.Errorf("expected %v, got %v", expected, result.Errors())
+			break
+		}
+	}
+
+	// (English): the json of the schema creates the same rules
+	//
+	// (Português): o json do esquema cria as mesmas regras
+	var data []byte
+	data, err = schema.JSONSchema()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var fromJSON = MongoDBJsonSchema{}
+	err = fromJSON.UnmarshalJSON(data)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if reflect.DeepEqual(fromJSON.Validate(invalid).Errors(), result.Errors()) == false {
+		t.Errorf("expected %v, got %v", result.Errors(), fromJSON.Validate(invalid).Errors())
+	}
+
+	var dataFromJSON []byte
+	dataFromJSON, err = fromJSON.JSONSchema()
+	if err != nil || string(dataFromJSON) != string(data) {
+		t.Errorf("expected %s, got %s (%v)", data, dataFromJSON, err)
+	}
+}
+
+func TestMongoDBJsonSchema_PopulateFromStructRecursive(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.PopulateFromStruct(populateFromStructTestNode{})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var result = schema.Validate(populateFromStructTestNode{
+		Name:     "root",
+		Children: []*populateFromStructTestNode{{Name: "leaf"}},
+	})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+}
+
+func TestMongoDBJsonSchema_PopulateFromStructError(t *testing.T) {
+	var schema = MongoDBJsonSchema{}
+	if schema.PopulateFromStruct(map[string]interface{}{}) == nil {
+		t.Errorf("a map must return an error")
+	}
+
+	if schema.PopulateFromStruct(struct {
+		Channel chan int `bson:"channel"`
+	}{}) == nil {
+		t.Errorf("a channel must return an error")
+	}
+
+	if schema.PopulateFromStruct(struct {
+		Name string `bson:"name" maxLength:"ten"`
+	}{}) == nil {
+		t.Errorf("an invalid tag must return an error")
+	}
+
+	if schema.PopulateFromStruct(struct {
+		CreatedAt time.Time `bson:"createdAt" maximum:"2020-01-01"`
+	}{}) == nil {
+		t.Errorf("maximum on a date must return an error")
+	}
+
+	if schema.PopulateFromStruct(struct {
+		Balance primitive.Decimal128 `bson:"balance" maximum:"ten"`
+	}{}) == nil {
+		t.Errorf("an invalid decimal bound must return an error")
+	}
+
+	var empty = MongoDBJsonSchema{}
+	if _, err := empty.JSONSchema(); err == nil {
+		t.Errorf("an empty schema must return an error")
+	}
+}
@@ -35,6 +35,23 @@ func (el *MongoDBJsonSchema) UnmarshalJSON(data []byte) (err error) {
 	return
 }
 
+// UnmarshalJSONWithOptions (English): Same as UnmarshalJSON(), but the parse options are
+// applied to all rules of the schema
+//
+// UnmarshalJSONWithOptions (Português): Igual a UnmarshalJSON(), mas as opções de parser
+// são aplicadas a todas as regras do esquema
+func (el *MongoDBJsonSchema) UnmarshalJSONWithOptions(data []byte, options ParseOptions) (err error) {
+	var schema = make(map[string]interface{})
+
+	err = json.Unmarshal(data, &schema)
+	if err != nil {
+		return
+	}
+
+	err = el.PopulateWithOptions(el.filterSchemaElements(schema), options)
+	return
+}
+
 //func (el *MongoDBJsonSchema) VerifyDocument(document map[string]interface{}) {
 //	if el.ErrorList == nil {
 //	  el.ErrorList = make([]error, 0)
@@ -0,0 +1,32 @@
+package iotmakerdbmongodbutilschema
+
+// Validate (English): Validates the document against the schema and returns every
+// violation found, with the document path, the schema keyword, the expected constraint
+// and the actual value.
+// The document can be a map[string]interface{}, bson.M, bson.D, bson.Raw or a struct
+// with bson tags, validated as it will be saved by the mongo driver.
+//
+//   result := schema.Validate(document)
+//   if result.Valid() == false {
+//     for _, violation := range result.Violations {
+//       log.Printf("%v: %v", violation.Path, violation.Keyword)
+//     }
+//   }
+//
+// Validate (Português): Valida o documento contra o esquema e retorna todas as violações
+// encontradas, com o caminho no documento, a palavra chave do esquema, a restrição
+// esperada e o valor encontrado.
+// O documento pode ser um map[string]interface{}, bson.M, bson.D, bson.Raw ou uma
+// struct com tags bson, validada como será gravada pelo driver do mongo.
+//
+//   result := schema.Validate(document)
+//   if result.Valid() == false {
+//     for _, violation := range result.Violations {
+//       log.Printf("%v: %v", violation.Path, violation.Keyword)
+//     }
+//   }
+func (el *MongoDBJsonSchema) Validate(document interface{}) (result ValidationResult) {
+	result.Violations = make([]Violation, 0)
+	el.TypeBsonObject.validate("", document, &result)
+	return
+}
@@ -0,0 +1,202 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const validateTestSchema = `
+{
+  "$jsonSchema": {
+    "bsonType": "object",
+    "required": ["name", "street"],
+    "properties": {
+      "_id": {
+        "bsonType": "objectId"
+      },
+      "name": {
+        "bsonType": "string",
+        "maxLength": 10,
+        "minLength": 3,
+        "pattern": "^[A-Z]"
+      },
+      "status": {
+        "bsonType": "string",
+        "enum": ["on", "off"]
+      },
+      "age": {
+        "bsonType": "int",
+        "minimum": 18,
+        "maximum": 99,
+        "multipleOf": 1
+      },
+      "active": {
+        "bsonType": ["bool", "int"]
+      },
+      "street": {
+        "bsonType": "object",
+        "required": ["number"],
+        "properties": {
+          "name": {
+            "bsonType": "string"
+          },
+          "number": {
+            "bsonType": "int",
+            "minimum": 1
+          }
+        }
+      },
+      "friends": {
+        "bsonType": "array",
+        "items": {
+          "bsonType": "object",
+          "properties": {
+            "name": {
+              "bsonType": "string",
+              "maxLength": 5
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+func validateTestGetSchema(t *testing.T) (schema MongoDBJsonSchema) {
+	var err error
+	err = schema.UnmarshalJSON([]byte(validateTestSchema))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	return
+}
+
+func validateTestFind(result ValidationResult, path, keyword string) (violation Violation, found bool) {
+	for _, violation = range result.Violations {
+		if violation.Path == path && violation.Keyword == keyword {
+			found = true
+			return
+		}
+	}
+
+	return
+}
+
+func TestMongoDBJsonSchema_Validate(t *testing.T) {
+	var schema = validateTestGetSchema(t)
+
+	var result = schema.Validate(map[string]interface{}{
+		"_id":    primitive.NewObjectID(),
+		"name":   "Dino Sauro",
+		"status": "on",
+		"age":    20,
+		"active": 1,
+		"street": map[string]interface{}{
+			"name":   "Main Street",
+			"number": 10,
+		},
+		"friends": []map[string]interface{}{
+			{"name": "Ana"},
+		},
+	})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+}
+
+func TestMongoDBJsonSchema_ValidateViolations(t *testing.T) {
+	var schema = validateTestGetSchema(t)
+
+	var result = schema.Validate(map[string]interface{}{
+		"_id":    "not an object id",
+		"name":   "dino sauro rex",
+		"status": "standby",
+		"age":    100,
+		"active": "yes",
+		"street": map[string]interface{}{
+			"name": 10,
+		},
+		"friends": []map[string]interface{}{
+			{"name": "Ana"},
+			{"name": "Roberto"},
+		},
+	})
+
+	var tests = []struct {
+		path    string
+		keyword string
+	}{
+		{"_id", "bsonType"},
+		{"name", "maxLength"},
+		{"status", "enum"},
+		{"age", "maximum"},
+		{"active", "bsonType"},
+		{"street.name", "bsonType"},
+		{"street.number", "required"},
+		{"friends.1.name", "maxLength"},
+	}
+
+	for _, test := range tests {
+		if _, found := validateTestFind(result, test.path, test.keyword); found == false {
+			t.Errorf("violation %v (%v) not found: %v", test.path, test.keyword, result.Errors())
+		}
+	}
+
+	if len(result.Violations) != len(tests) {
+		t.Errorf("expected %v violations, got %v: %v", len(tests), len(result.Violations), result.Errors())
+	}
+
+	violation, _ := validateTestFind(result, "name", "maxLength")
+	if violation.Expected != int64(10) || violation.Actual != "dino sauro rex" {
+		t.Fail()
+	}
+
+	violation, _ = validateTestFind(result, "active", "bsonType")
+	if fmt.Sprint(violation.Expected) != "[bool int]" {
+		t.Fail()
+	}
+}
+
+func TestMongoDBJsonSchema_ValidateRequired(t *testing.T) {
+	var schema = validateTestGetSchema(t)
+
+	var result = schema.Validate(map[string]interface{}{})
+	if len(result.Violations) != 2 {
+		t.FailNow()
+	}
+
+	if result.Violations[0].Path != "name" || result.Violations[1].Path != "street" {
+		t.Fail()
+	}
+
+	if len(result.Filter("required")) != 2 {
+		t.Fail()
+	}
+}
+
+func ExampleMongoDBJsonSchema_Validate() {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(validateTestSchema))
+	if err != nil {
+		fmt.Printf("error: %v\n", err.Error())
+		return
+	}
+
+	var result = schema.Validate(map[string]interface{}{
+		"name": "Dino",
+		"street": map[string]interface{}{
+			"number": 0,
+		},
+	})
+	for _, violation := range result.Violations {
+		fmt.Printf("%v: %v\n", violation.Keyword, violation.Error())
+	}
+
+	// Output:
+	// minimum: street.number: expected minimum value
+}
@@ -0,0 +1,127 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"errors"
+	"sort"
+)
+
+// ParseOptions (English): Options used to parse a schema. The options are copied into
+// every rule created from the schema, so two schemas in the same process can use
+// different conventions. The zero value keeps the default behavior.
+//
+//   var schema = MongoDBJsonSchema{}
+//   err = schema.UnmarshalJSONWithOptions(data, ParseOptions{
+//     DateLayouts: []string{"2006-01-02"},
+//     AcceptDoubleConvertedToInteger: true,
+//     Strict: true,
+//   })
+//
+// ParseOptions (Português): Opções usadas no parser de um esquema. As opções são copiadas
+// para todas as regras criadas a partir do esquema, assim, dois esquemas no mesmo
+// processo podem usar convenções diferentes. O valor zero mantém o comportamento padrão.
+type ParseOptions struct {
+	// Layouts used to parse date bounds written as strings, tried in order after RFC 3339.
+	// When empty, the layout defined by DefineNewDateLayout() when the schema is populated
+	// is used.
+	DateLayouts []string
+
+	// Accepts double values without fractional part, such as 2.0, as int and long.
+	AcceptDoubleConvertedToInteger bool
+
+	// Accepts only the Go types that the mongo driver stores with the expected bsonType:
+	// int means int32, long means int64, double means float64 and decimal means
+	// primitive.Decimal128.
+	StrictNumericTypes bool
+
+	// Rejects schema documents with keywords that are not part of the MongoDB $jsonSchema
+	// or of the enabled extensions of this module.
+	Strict bool
+
+	// Rejects the keywords that are extensions of this module, such as 'binDataSubType',
+	// so the schema can be sent to a MongoDB server as is.
+	DisableExtensions bool
+}
+
+// schemaKeywordList: keywords of the MongoDB $jsonSchema
+var schemaKeywordList = map[string]bool{
+	"bsonType":             true,
+	"type":                 true,
+	"enum":                 true,
+	"title":                true,
+	"description":          true,
+	"allOf":                true,
+	"anyOf":                true,
+	"oneOf":                true,
+	"not":                  true,
+	"properties":           true,
+	"required":             true,
+	"minProperties":        true,
+	"maxProperties":        true,
+	"patternProperties":    true,
+	"additionalProperties": true,
+	"dependencies":         true,
+	"items":                true,
+	"additionalItems":      true,
+	"maxItems":             true,
+	"minItems":             true,
+	"uniqueItems":          true,
+	"maxLength":            true,
+	"minLength":            true,
+	"pattern":              true,
+	"multipleOf":           true,
+	"maximum":              true,
+	"exclusiveMaximum":     true,
+	"minimum":              true,
+	"exclusiveMinimum":     true,
+}
+
+// schemaExtensionKeywordList: keywords created by this module and not provided by the
+// MongoDB $jsonSchema
+var schemaExtensionKeywordList = map[string]bool{
+	"binDataSubType":   true,
+	"binDataMinLength": true,
+	"binDataMaxLength": true,
+
+	"timestampMaximum":          true,
+	"timestampExclusiveMaximum": true,
+	"timestampMinimum":          true,
+	"timestampExclusiveMinimum": true,
+}
+
+// setParseOptions (English): Defines the parse options used by Populate() and by the
+// rules created from it
+//
+// setParseOptions (Português): Define as opções de parser usadas por Populate() e pelas
+// regras criadas a partir dele
+func (el *TypeBsonCommonToAllTypes) setParseOptions(options ParseOptions) {
+	el.options = options
+	el.AcceptDoubleConvertedToInteger = options.AcceptDoubleConvertedToInteger
+}
+
+// verifyKeywords (English): Verifies the keywords of the schema document against the
+// parse options
+//
+// verifyKeywords (Português): Verifica as palavras chave do documento de esquema de
+// acordo com as opções de parser
+func (el *TypeBsonCommonToAllTypes) verifyKeywords(schema map[string]interface{}) (err error) {
+	var keyList = make([]string, 0, len(schema))
+	for key := range schema {
+		keyList = append(keyList, key)
+	}
+
+	sort.Strings(keyList)
+
+	for _, key := range keyList {
+		if schemaExtensionKeywordList[key] == true && el.options.DisableExtensions == true {
+			err = errors.New("'" + key + "' is an extension of this module and the extensions are disabled")
+			return
+		}
+
+		if el.options.Strict == true && schemaKeywordList[key] == false && schemaExtensionKeywordList[key] == false {
+			err = errors.New("'" + key + "' is not a keyword of the MongoDB $jsonSchema")
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,178 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseOptions_DateLayouts(t *testing.T) {
+	var wg sync.WaitGroup
+	var layoutList = []string{"2006-01-02", "02/01/2006"}
+	var dateList = []string{"2020-01-31", "31/01/2020"}
+
+	for k := range layoutList {
+		wg.Add(1)
+		go func(layout, date string) {
+			defer wg.Done()
+
+			for i := 0; i != 50; i += 1 {
+				var schema = MongoDBJsonSchema{}
+				var err = schema.UnmarshalJSONWithOptions([]byte(`
+        {
+          "properties": {
+            "list": {
+              "bsonType": "array",
+              "items": {
+                "anyOf": [{ "bsonType": "date", "minimum": "`+date+`" }]
+              }
+            }
+          }
+        }
+        `), ParseOptions{DateLayouts: []string{layout}})
+				if err != nil {
+					t.Errorf("%v: error: %v", layout, err)
+					return
+				}
+
+				var result = schema.Validate(map[string]interface{}{
+					"list": []interface{}{time.Date(2020, 1, 30, 0, 0, 0, 0, time.UTC)},
+				})
+				if len(result.Violations) != 1 || result.Violations[0].Keyword != "anyOf" {
+					t.Errorf("%v: unexpected violations: %v", layout, result.Errors())
+					return
+				}
+			}
+		}(layoutList[k], dateList[k])
+	}
+
+	wg.Wait()
+
+	var schema = MongoDBJsonSchema{}
+	var err = schema.UnmarshalJSONWithOptions([]byte(`{"properties": {"a": {"bsonType": "date", "minimum": "2020-01-31"}}}`), ParseOptions{DateLayouts: []string{"02/01/2006"}})
+	if err == nil {
+		t.Errorf("error expected")
+	}
+}
+
+func TestParseOptions_DefineNewDateLayout(t *testing.T) {
+	var defaultLayout = dateLayout
+	defer DefineNewDateLayout(defaultLayout)
+
+	DefineNewDateLayout("2006-01-02")
+
+	var schema = MongoDBJsonSchema{}
+	var err = schema.UnmarshalJSON([]byte(`{"properties": {"a": {"bsonType": "date", "minimum": "2020-01-31"}}}`))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	// (English): the layout is copied when the schema is populated, so the schema and its
+	// validators do not read the global layout again
+	//
+	// (Português): o layout é copiado quando o esquema é populado, assim o esquema e seus
+	// validadores não leem o layout global novamente
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i != 50; i += 1 {
+			DefineNewDateLayout("02/01/2006")
+		}
+	}()
+
+	for i := 0; i != 50; i += 1 {
+		var validator *Validator
+		validator, err = schema.Compile()
+		if err != nil {
+			t.Errorf("error: %v", err)
+			break
+		}
+
+		var result = validator.Validate(map[string]interface{}{"a": time.Date(2020, 1, 30, 0, 0, 0, 0, time.UTC)})
+		if len(result.Violations) != 1 || result.Violations[0].Keyword != "minimum" {
+			t.Errorf("unexpected violations: %v", result.Errors())
+			break
+		}
+	}
+
+	wg.Wait()
+}
+
+func TestParseOptions_AcceptDoubleConvertedToInteger(t *testing.T) {
+	var jsonSchema = []byte(`
+  {
+    "properties": {
+      "count": { "bsonType": "long" },
+      "nested": {
+        "bsonType": "object",
+        "patternProperties": { "^n": { "bsonType": "int" } }
+      }
+    }
+  }
+  `)
+	var document = map[string]interface{}{
+		"count":  2.0,
+		"nested": map[string]interface{}{"n1": 3.0},
+	}
+
+	var schema = MongoDBJsonSchema{}
+	var err = schema.UnmarshalJSON(jsonSchema)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if len(schema.Validate(document).Filter("bsonType")) != 2 {
+		t.Errorf("double must be rejected by default: %v", schema.Validate(document).Errors())
+	}
+
+	err = schema.UnmarshalJSONWithOptions(jsonSchema, ParseOptions{AcceptDoubleConvertedToInteger: true})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var result = schema.Validate(document)
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	validator, err := schema.Compile()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	result = validator.Validate(document)
+	if result.Valid() == false {
+		t.Errorf("the compiled validator must keep the options: %v", result.Errors())
+	}
+}
+
+func TestParseOptions_StrictAndExtensions(t *testing.T) {
+	var tests = []struct {
+		jsonSchema string
+		options    ParseOptions
+		valid      bool
+	}{
+		{`{"properties": {"a": {"bsonType": "string", "format": "email"}}}`, ParseOptions{}, true},
+		{`{"properties": {"a": {"bsonType": "string", "format": "email"}}}`, ParseOptions{Strict: true}, false},
+		{`{"properties": {"a": {"items": {"x-unit": "m"}}}}`, ParseOptions{Strict: true}, false},
+		{`{"properties": {"a": {"not": {"x-unit": "m"}}}}`, ParseOptions{Strict: true}, false},
+		{`{"properties": {"a": {"bsonType": "binData", "binDataSubType": 4}}}`, ParseOptions{Strict: true}, true},
+		{`{"properties": {"a": {"bsonType": "binData", "binDataSubType": 4}}}`, ParseOptions{DisableExtensions: true}, false},
+		{`{"properties": {"a": {"bsonType": "timestamp", "timestampMinimum": {"t": 1, "i": 0}}}}`, ParseOptions{Strict: true}, true},
+		{`{"properties": {"a": {"bsonType": "timestamp", "timestampMinimum": {"t": 1, "i": 0}}}}`, ParseOptions{DisableExtensions: true}, false},
+		{`{"title": "t", "required": ["a"], "properties": {"a": {"bsonType": "int", "minimum": 1}}}`, ParseOptions{Strict: true, DisableExtensions: true}, true},
+	}
+
+	for _, test := range tests {
+		var schema = MongoDBJsonSchema{}
+		var err = schema.UnmarshalJSONWithOptions([]byte(test.jsonSchema), test.options)
+		if test.valid == true && err != nil {
+			t.Errorf("%v (%+v): error: %v", test.jsonSchema, test.options, err)
+		}
+
+		if test.valid == false && err == nil {
+			t.Errorf("%v (%+v): error expected", test.jsonSchema, test.options)
+		}
+	}
+}
@@ -5,12 +5,51 @@ import (
 	"regexp"
 )
 
-// PatternProperties (English):
+// PatternProperties (English): Rules applied to all keys of the document that match the
+// regular expression
 //
-// PatternProperties (Português):
+//   Example:
+//   {
+//     "patternProperties": {
+//       "^sensor_[0-9]+$": { "bsonType": "double" }
+//     }
+//   }
+//
+// PatternProperties (Português): Regras aplicadas a todas as chaves do documento que
+// casam com a expressão regular
 type PatternProperties struct {
 	regexp     *regexp.Regexp
 	properties []MongoDBJsonSchema
+
+	// rules of the schema document, one for each bsonType
+	rules map[string]BsonType
+}
+
+// GetRegexp (English): Returns the regular expression of the property
+//
+// GetRegexp (Português): Retorna a expressão regular da propriedade
+func (el *PatternProperties) GetRegexp() (value *regexp.Regexp) {
+	return el.regexp
+}
+
+// GetRules (English): Returns the rules, one for each bsonType, applied to the keys that
+// match the regular expression
+//
+// GetRules (Português): Retorna as regras, uma para cada bsonType, aplicadas às chaves
+// que casam com a expressão regular
+func (el *PatternProperties) GetRules() (rules map[string]BsonType) {
+	return el.rules
+}
+
+// MatchString (English): Returns true if the key name matches the regular expression
+//
+// MatchString (Português): Retorna true se o nome da chave casa com a expressão regular
+func (el *PatternProperties) MatchString(key string) bool {
+	if el.regexp == nil {
+		return false
+	}
+
+	return el.regexp.MatchString(key)
 }
 
 // AppendProperty (English): Add a new property
@@ -46,11 +85,8 @@ func (el *PatternProperties) SetRegexpPOSIX(value string) (err error) {
 func (el *PatternProperties) GetMatch(value string) (propertiesList []MongoDBJsonSchema, err error) {
 	propertiesList = make([]MongoDBJsonSchema, 0)
 
-	if el.regexp.MatchString(value) == true {
-		if len(el.properties) == 0 {
-			el.properties = make([]MongoDBJsonSchema, 0)
-		}
-		propertiesList = el.properties
+	if el.MatchString(value) == true {
+		propertiesList = append(propertiesList, el.properties...)
 		return
 	}
 
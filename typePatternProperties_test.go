@@ -0,0 +1,91 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+)
+
+func TestPatternProperties_Validate(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "sensor_000": { "bsonType": "double", "minimum": 0 }
+    },
+    "patternProperties": {
+      "^sensor_[0-9]+$": { "bsonType": "double", "maximum": 100 },
+      "_042$": { "bsonType": "double", "multipleOf": 2 },
+      "^config": {
+        "bsonType": "object",
+        "required": ["unit"],
+        "properties": {
+          "unit": { "enum": ["C", "F"] }
+        }
+      }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if len(schema.PatternProperties) != 3 || schema.PatternProperties[0].GetRegexp().String() != "^config" {
+		t.Fatalf("unexpected pattern properties: %v", schema.PatternProperties)
+	}
+
+	var result = schema.Validate(map[string]interface{}{
+		"sensor_000": 10.0,
+		"sensor_042": 42.0,
+		"sensor_abc": "not a sensor",
+		"configA":    map[string]interface{}{"unit": "C"},
+	})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{
+		"sensor_000": -1.0,
+		"sensor_001": 101.0,
+		"sensor_042": 101.0,
+		"configB":    map[string]interface{}{"unit": "K"},
+		"configC":    map[string]interface{}{},
+	})
+
+	var tests = []struct {
+		path    string
+		keyword string
+	}{
+		{"configB.unit", "enum"},
+		{"configC.unit", "required"},
+		{"sensor_000", "minimum"},
+		{"sensor_001", "maximum"},
+		{"sensor_042", "maximum"},
+		{"sensor_042", "multipleOf"},
+	}
+
+	for _, test := range tests {
+		if _, found := validateTestFind(result, test.path, test.keyword); found == false {
+			t.Errorf("violation %v (%v) not found: %v", test.path, test.keyword, result.Errors())
+		}
+	}
+
+	if len(result.Violations) != len(tests) {
+		t.Errorf("expected %v violations, got: %v", len(tests), result.Errors())
+	}
+}
+
+func TestPatternProperties_PopulateError(t *testing.T) {
+	var schemaList = []string{
+		`{"patternProperties": {"[a-": {"bsonType": "string"}}}`,
+		`{"patternProperties": {"^a": "string"}}`,
+		`{"patternProperties": ["^a"]}`,
+	}
+
+	for _, jsonSchema := range schemaList {
+		var schema = MongoDBJsonSchema{}
+		if schema.UnmarshalJSON([]byte(jsonSchema)) == nil {
+			t.Errorf("%v: error expected", jsonSchema)
+		}
+	}
+}
@@ -0,0 +1,588 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// inferenceBsonTypeList (English): bsonType of the Go types that are not numbers,
+// documents or arrays
+//
+// inferenceBsonTypeList (Português): bsonType dos tipos Go que não são números,
+// documentos ou arrays
+var inferenceBsonTypeList = map[reflect.Type]string{
+	reflect.TypeOf(true):                      "bool",
+	reflect.TypeOf(""):                        "string",
+	reflect.TypeOf(time.Time{}):               "date",
+	reflect.TypeOf(primitive.DateTime(0)):     "date",
+	reflect.TypeOf(primitive.ObjectID{}):      "objectId",
+	reflect.TypeOf(primitive.Timestamp{}):     "timestamp",
+	reflect.TypeOf(primitive.Binary{}):        "binData",
+	reflect.TypeOf([]byte{}):                  "binData",
+	reflect.TypeOf(primitive.Regex{}):         "regex",
+	reflect.TypeOf(primitive.JavaScript("")):  "javascript",
+	reflect.TypeOf(primitive.Symbol("")):      "symbol",
+	reflect.TypeOf(primitive.CodeWithScope{}): "javascriptWithScope",
+	reflect.TypeOf(primitive.DBPointer{}):     "dbPointer",
+	reflect.TypeOf(primitive.MinKey{}):        "minKey",
+	reflect.TypeOf(primitive.MaxKey{}):        "maxKey",
+	reflect.TypeOf(primitive.Undefined{}):     "undefined",
+	reflect.TypeOf(primitive.Null{}):          "null",
+}
+
+// SchemaInference (English): Proposes a $jsonSchema from sample documents of a
+// collection without validator.
+//
+// Each field is described by the bsonType of all values found; the fields present in all
+// documents are required; strings with few distinct values have an enum and numbers have
+// minimum and maximum.
+//
+//   var inference = SchemaInference{}
+//   for cursor.Next(ctx) {
+//     err = inference.Add(cursor.Current)
+//     ...
+//   }
+//
+//   schema, err = inference.GetMongoDBJsonSchema()
+//   ...
+//   for _, statistics := range inference.GetStatistics() {
+//     log.Printf("%v: %v of %v", statistics.Path, statistics.Count, statistics.ParentCount)
+//   }
+//
+// SchemaInference is not safe for concurrent use.
+//
+// SchemaInference (Português): Propõe um $jsonSchema a partir de documentos de amostra de
+// uma coleção sem validador.
+//
+// Cada campo é descrito pelo bsonType de todos os valores encontrados; os campos
+// presentes em todos os documentos são requeridos; strings com poucos valores distintos
+// têm um enum e números têm minimum e maximum.
+//
+//   var inference = SchemaInference{}
+//   for cursor.Next(ctx) {
+//     err = inference.Add(cursor.Current)
+//     ...
+//   }
+//
+//   schema, err = inference.GetMongoDBJsonSchema()
+//   ...
+//   for _, statistics := range inference.GetStatistics() {
+//     log.Printf("%v: %v of %v", statistics.Path, statistics.Count, statistics.ParentCount)
+//   }
+//
+// SchemaInference não é seguro para uso concorrente.
+type SchemaInference struct {
+	// Default: 10
+	// Maximum number of distinct values of a string field with enum. The enum is only
+	// proposed when at least one value repeats.
+	EnumMaxValues int
+
+	// Disables the enum of string fields
+	DisableEnum bool
+
+	common TypeBsonCommonToAllTypes
+	root   *inferenceField
+}
+
+// FieldStatistics (English): Values found for one field of the sample documents. Items
+// of arrays have the path of the array followed by "[]", as in "friends.[].name".
+//
+// FieldStatistics (Português): Valores encontrados para um campo dos documentos de
+// amostra. Itens de arrays têm o caminho do array seguido de "[]", como em
+// "friends.[].name".
+type FieldStatistics struct {
+	Path string
+
+	// Number of documents, or sub documents, where the field was present. For the items
+	// of arrays, "friends.[]", the number of items.
+	Count int64
+
+	// Number of documents, or sub documents, where the field could be present. For the
+	// items of arrays, "friends.[]", the number of arrays, and for the fields of the
+	// items, "friends.[].name", the number of items.
+	ParentCount int64
+
+	// Count / ParentCount. For the items of arrays, the average number of items by array.
+	Frequency float64
+
+	// Number of values of each bsonType
+	Types map[string]int64
+
+	// Smallest and largest number found, nil without numbers
+	Minimum *big.Rat
+	Maximum *big.Rat
+
+	// Number of times each string was found, nil when there are more than EnumMaxValues
+	// distinct strings
+	StringValues map[string]int64
+}
+
+// inferenceField (English): Values found for one field, or for the items of an array
+//
+// inferenceField (Português): Valores encontrados para um campo, ou para os itens de um
+// array
+type inferenceField struct {
+	count     int64
+	typeCount map[string]int64
+
+	// sub documents
+	objectCount int64
+	properties  map[string]*inferenceField
+	keyList     []string
+
+	// items of arrays
+	items *inferenceField
+
+	// numbers
+	minimum *big.Rat
+	maximum *big.Rat
+
+	// strings, nil when there are more than EnumMaxValues distinct strings
+	stringCount  int64
+	stringValues map[string]int64
+}
+
+// Add (English): Adds one sample document: map[string]interface{}, bson.M, bson.D,
+// bson.Raw or a struct with bson tags
+//
+// Add (Português): Adiciona um documento de amostra: map[string]interface{}, bson.M,
+// bson.D, bson.Raw ou uma struct com tags bson
+func (el *SchemaInference) Add(document interface{}) (err error) {
+	var view documentView
+	view, err = el.common.parentConvertInterfaceToDocumentView(document)
+	if err != nil {
+		err = errors.New("the sample must be a document: " + err.Error())
+		return
+	}
+
+	if el.root == nil {
+		el.root = el.newField()
+	}
+
+	el.root.count++
+	el.root.typeCount["object"]++
+	el.addDocument(el.root, view)
+	return
+}
+
+// AddNDJSON (English): Adds the documents of a mongoexport file, with one extended json
+// document for each line
+//
+// AddNDJSON (Português): Adiciona os documentos de um arquivo do mongoexport, com um
+// documento em extended json para cada linha
+func (el *SchemaInference) AddNDJSON(reader io.Reader) (err error) {
+	var bufferedReader = bufio.NewReader(reader)
+	for line := 1; ; line++ {
+		var data []byte
+		data, err = bufferedReader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return
+		}
+
+		var end = err == io.EOF
+		err = nil
+
+		data = bytes.TrimSpace(data)
+		if len(data) != 0 {
+			var document bson.D
+			err = bson.UnmarshalExtJSON(data, false, &document)
+			if err != nil {
+				err = errors.New("line " + strconv.Itoa(line) + ": " + err.Error())
+				return
+			}
+
+			err = el.Add(document)
+			if err != nil {
+				err = errors.New("line " + strconv.Itoa(line) + ": " + err.Error())
+				return
+			}
+		}
+
+		if end == true {
+			return
+		}
+	}
+}
+
+// AddBSON (English): Adds the documents of a mongodump .bson file
+//
+// AddBSON (Português): Adiciona os documentos de um arquivo .bson do mongodump
+func (el *SchemaInference) AddBSON(reader io.Reader) (err error) {
+	for {
+		var document bson.Raw
+		document, err = bson.NewFromIOReader(reader)
+		if err == io.EOF {
+			err = nil
+			return
+		}
+
+		if err != nil {
+			return
+		}
+
+		err = el.Add(document)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// GetSchema (English): Returns the proposed $jsonSchema, nil without samples
+//
+// GetSchema (Português): Retorna o $jsonSchema proposto, nil sem amostras
+func (el *SchemaInference) GetSchema() (schema map[string]interface{}) {
+	if el.root == nil {
+		return
+	}
+
+	return el.fieldToSchema(el.root)
+}
+
+// GetMongoDBJsonSchema (English): Returns the proposed schema populated
+//
+// GetMongoDBJsonSchema (Português): Retorna o esquema proposto populado
+func (el *SchemaInference) GetMongoDBJsonSchema() (schema *MongoDBJsonSchema, err error) {
+	if el.root == nil {
+		err = errors.New("no sample document was added")
+		return
+	}
+
+	schema = &MongoDBJsonSchema{}
+	err = schema.Populate(el.GetSchema())
+	if err != nil {
+		schema = nil
+	}
+
+	return
+}
+
+// GetStatistics (English): Returns the statistics of all fields, in the order the fields
+// were found
+//
+// GetStatistics (Português): Retorna as estatísticas de todos os campos, na ordem em que
+// os campos foram encontrados
+func (el *SchemaInference) GetStatistics() (statisticsList []FieldStatistics) {
+	statisticsList = make([]FieldStatistics, 0)
+	if el.root == nil {
+		return
+	}
+
+	el.appendStatistics(&statisticsList, "", el.root, el.root.objectCount)
+	return
+}
+
+// appendStatistics (English): Appends the statistics of the fields of the document and
+// of the items of the array. parentCount is the number of values where the fields could
+// be present.
+//
+// appendStatistics (Português): Acrescenta as estatísticas dos campos do documento e dos
+// itens do array. parentCount é o número de valores onde os campos poderiam estar
+// presentes.
+func (el *SchemaInference) appendStatistics(statisticsList *[]FieldStatistics, path string, field *inferenceField, parentCount int64) {
+	for _, key := range field.keyList {
+		var property = field.properties[key]
+		var propertyPath = joinPath(path, key)
+
+		*statisticsList = append(*statisticsList, el.newStatistics(propertyPath, property, parentCount))
+		el.appendStatistics(statisticsList, propertyPath, property, property.objectCount)
+	}
+
+	if field.items != nil {
+		var itemsPath = joinPath(path, "[]")
+
+		// (English): the items are counted against the arrays and the fields of the items
+		// against all items of the arrays
+		//
+		// (Português): os itens são contados em relação aos arrays e os campos dos itens em
+		// relação a todos os itens dos arrays
+		*statisticsList = append(*statisticsList, el.newStatistics(itemsPath, field.items, field.typeCount["array"]))
+		el.appendStatistics(statisticsList, itemsPath, field.items, field.items.count)
+	}
+}
+
+// newStatistics (English): Creates the statistics of one field
+//
+// newStatistics (Português): Cria as estatísticas de um campo
+func (el *SchemaInference) newStatistics(path string, field *inferenceField, parentCount int64) (statistics FieldStatistics) {
+	statistics = FieldStatistics{
+		Path:        path,
+		Count:       field.count,
+		ParentCount: parentCount,
+		Types:       make(map[string]int64, len(field.typeCount)),
+	}
+
+	if parentCount != 0 {
+		statistics.Frequency = float64(field.count) / float64(parentCount)
+	}
+
+	for bsonType, count := range field.typeCount {
+		statistics.Types[bsonType] = count
+	}
+
+	if field.minimum != nil {
+		statistics.Minimum = new(big.Rat).Set(field.minimum)
+		statistics.Maximum = new(big.Rat).Set(field.maximum)
+	}
+
+	if field.stringValues != nil {
+		statistics.StringValues = make(map[string]int64, len(field.stringValues))
+		for value, count := range field.stringValues {
+			statistics.StringValues[value] = count
+		}
+	}
+
+	return
+}
+
+// newField (English): Creates the statistics of a field without values
+//
+// newField (Português): Cria as estatísticas de um campo sem valores
+func (el *SchemaInference) newField() (field *inferenceField) {
+	return &inferenceField{
+		typeCount:    make(map[string]int64),
+		properties:   make(map[string]*inferenceField),
+		keyList:      make([]string, 0),
+		stringValues: make(map[string]int64),
+	}
+}
+
+// getEnumMaxValues (English): Returns EnumMaxValues or the default value
+//
+// getEnumMaxValues (Português): Retorna EnumMaxValues ou o valor padrão
+func (el *SchemaInference) getEnumMaxValues() int {
+	if el.EnumMaxValues <= 0 {
+		return 10
+	}
+
+	return el.EnumMaxValues
+}
+
+// addDocument (English): Adds the fields of one document, or sub document
+//
+// addDocument (Português): Adiciona os campos de um documento, ou sub documento
+func (el *SchemaInference) addDocument(field *inferenceField, document documentView) {
+	field.objectCount++
+
+	for _, key := range document.keyList {
+		property, found := field.properties[key]
+		if found == false {
+			property = el.newField()
+			field.properties[key] = property
+			field.keyList = append(field.keyList, key)
+		}
+
+		el.addValue(property, document.values[key])
+	}
+}
+
+// addValue (English): Adds one value of the field
+//
+// addValue (Português): Adiciona um valor do campo
+func (el *SchemaInference) addValue(field *inferenceField, value interface{}) {
+	field.count++
+
+	if el.common.parentIsNull(value) == true {
+		field.typeCount["null"]++
+		return
+	}
+
+	if el.common.parentVerifyInterfaceTypeIsDocument(value) == nil {
+		document, err := el.common.parentConvertInterfaceToDocumentView(value)
+		if err == nil {
+			field.typeCount["object"]++
+			el.addDocument(field, document)
+		}
+		return
+	}
+
+	// (English): primitive.DateTime is an int64 and must not be a number
+	//
+	// (Português): primitive.DateTime é um int64 e não deve ser um número
+	if bsonType, found := inferenceBsonTypeList[reflect.TypeOf(value)]; found == true {
+		field.typeCount[bsonType]++
+		if bsonType == "string" {
+			el.addString(field, value.(string))
+		}
+		return
+	}
+
+	if numericType := el.common.parentGetStoredNumericType(value); numericType != "" {
+		field.typeCount[numericType]++
+		el.addNumber(field, value)
+		return
+	}
+
+	list, err := el.common.parentConvertInterfaceToSlice(value)
+	if err != nil {
+		return
+	}
+
+	field.typeCount["array"]++
+	if field.items == nil {
+		field.items = el.newField()
+	}
+
+	for _, item := range list {
+		el.addValue(field.items, item)
+	}
+}
+
+// addNumber (English): Updates the minimum and the maximum of the field
+//
+// addNumber (Português): Atualiza o mínimo e o máximo do campo
+func (el *SchemaInference) addNumber(field *inferenceField, value interface{}) {
+	number, isNumber := el.common.parentConvertInterfaceToRat(value)
+	if isNumber == false {
+		return
+	}
+
+	if field.minimum == nil || number.Cmp(field.minimum) < 0 {
+		field.minimum = number
+	}
+
+	if field.maximum == nil || number.Cmp(field.maximum) > 0 {
+		field.maximum = number
+	}
+}
+
+// addString (English): Counts the distinct strings of the field, up to EnumMaxValues
+//
+// addString (Português): Conta as strings distintas do campo, até EnumMaxValues
+func (el *SchemaInference) addString(field *inferenceField, value string) {
+	field.stringCount++
+	if field.stringValues == nil {
+		return
+	}
+
+	field.stringValues[value]++
+	if len(field.stringValues) > el.getEnumMaxValues() {
+		field.stringValues = nil
+	}
+}
+
+// fieldToSchema (English): Creates the schema document of the field
+//
+// fieldToSchema (Português): Cria o documento de esquema do campo
+func (el *SchemaInference) fieldToSchema(field *inferenceField) (schema map[string]interface{}) {
+	schema = make(map[string]interface{})
+
+	var typeList = make([]string, 0, len(field.typeCount))
+	for bsonType := range field.typeCount {
+		typeList = append(typeList, bsonType)
+	}
+	sort.Strings(typeList)
+
+	switch len(typeList) {
+	case 0:
+		return
+	case 1:
+		schema["bsonType"] = typeList[0]
+	default:
+		var bsonTypeList = make([]interface{}, len(typeList))
+		for k := range typeList {
+			bsonTypeList[k] = typeList[k]
+		}
+		schema["bsonType"] = bsonTypeList
+	}
+
+	if field.typeCount["object"] != 0 {
+		var properties = make(map[string]interface{}, len(field.keyList))
+		var required = make([]interface{}, 0)
+		for _, key := range field.keyList {
+			var property = field.properties[key]
+			properties[key] = el.fieldToSchema(property)
+
+			if property.count == field.objectCount {
+				required = append(required, key)
+			}
+		}
+
+		schema["properties"] = properties
+		if len(required) != 0 {
+			schema["required"] = required
+		}
+	}
+
+	if field.items != nil && field.items.count != 0 {
+		schema["items"] = el.fieldToSchema(field.items)
+	}
+
+	el.numberToSchema(field, schema)
+
+	// (English): the enum applies to all types, so it is only proposed for fields that
+	// are always strings
+	//
+	// (Português): o enum se aplica a todos os tipos, então só é proposto para campos
+	// que são sempre strings
+	if el.DisableEnum == false && len(typeList) == 1 && typeList[0] == "string" && field.stringValues != nil && field.stringCount > int64(len(field.stringValues)) {
+		var valueList = make([]string, 0, len(field.stringValues))
+		for value := range field.stringValues {
+			valueList = append(valueList, value)
+		}
+		sort.Strings(valueList)
+
+		var enum = make([]interface{}, len(valueList))
+		for k := range valueList {
+			enum[k] = valueList[k]
+		}
+		schema["enum"] = enum
+	}
+
+	return
+}
+
+// numberToSchema (English): Adds minimum and maximum to the schema of the field, with the
+// type of the numbers found: int and long use integers, double uses float64 and decimal
+// alone uses float64 rounded outward, so every sample stays inside the bounds
+//
+// numberToSchema (Português): Adiciona minimum e maximum ao esquema do campo, com o tipo
+// dos números encontrados: int e long usam inteiros, double usa float64 e decimal sozinho
+// usa float64 arredondado para fora, para que todas as amostras fiquem dentro dos limites
+func (el *SchemaInference) numberToSchema(field *inferenceField, schema map[string]interface{}) {
+	if field.minimum == nil {
+		return
+	}
+
+	var hasDecimal = field.typeCount["decimal"] != 0
+	var hasDouble = field.typeCount["double"] != 0
+	var hasInteger = field.typeCount["int"] != 0 || field.typeCount["long"] != 0
+
+	switch {
+	case hasDecimal == true && (hasDouble == true || hasInteger == true):
+		return
+
+	case hasDecimal == true:
+		var minimum, maximum float64
+		var minimumOk, maximumOk bool
+		minimum, minimumOk = ratToFloat64Bound(field.minimum, -1)
+		maximum, maximumOk = ratToFloat64Bound(field.maximum, 1)
+		if minimumOk == false || maximumOk == false {
+			return
+		}
+
+		schema["minimum"] = minimum
+		schema["maximum"] = maximum
+
+	case hasDouble == true:
+		schema["minimum"], _ = field.minimum.Float64()
+		schema["maximum"], _ = field.maximum.Float64()
+
+	default:
+		if field.minimum.Num().IsInt64() == false || field.maximum.Num().IsInt64() == false {
+			return
+		}
+
+		schema["minimum"] = field.minimum.Num().Int64()
+		schema["maximum"] = field.maximum.Num().Int64()
+	}
+}
@@ -0,0 +1,354 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func schemaInferenceTestSampleList(t *testing.T) (sampleList []interface{}) {
+	var price, _ = primitive.ParseDecimal128("10.25")
+	var raw, err = bson.Marshal(bson.D{
+		{Key: "_id", Value: primitive.NewObjectID()},
+		{Key: "name", Value: "Carla"},
+		{Key: "status", Value: "on"},
+		{Key: "age", Value: int32(40)},
+		{Key: "code", Value: "A1"},
+		{Key: "price", Value: price},
+		{Key: "score", Value: 0.5},
+		{Key: "address", Value: bson.D{{Key: "street", Value: "Main"}}},
+		{Key: "tags", Value: bson.A{"a", "b"}},
+	})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	price, _ = primitive.ParseDecimal128("0.001")
+	sampleList = []interface{}{
+		map[string]interface{}{
+			"_id":     primitive.NewObjectID(),
+			"name":    "Ana",
+			"status":  "on",
+			"age":     18,
+			"code":    10,
+			"nick":    nil,
+			"price":   price,
+			"score":   10,
+			"address": map[string]interface{}{"street": "Elm", "number": 10},
+			"tags":    []string{"a"},
+		},
+		bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "name", Value: "Bruno"},
+			{Key: "status", Value: "off"},
+			{Key: "age", Value: int64(1) << 40},
+			{Key: "code", Value: "B2"},
+			{Key: "nick", Value: "bb"},
+			{Key: "score", Value: -1.25},
+			{Key: "address", Value: bson.M{"street": "Oak"}},
+			{Key: "tags", Value: bson.A{}},
+			{Key: "createdAt", Value: time.Now()},
+		},
+		bson.Raw(raw),
+	}
+	return
+}
+
+func TestSchemaInference_GetSchema(t *testing.T) {
+	var err error
+	var inference = SchemaInference{}
+	for _, sample := range schemaInferenceTestSampleList(t) {
+		err = inference.Add(sample)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	var schema = inference.GetSchema()
+	if schema["bsonType"] != "object" {
+		t.Errorf("unexpected bsonType: %v", schema["bsonType"])
+	}
+
+	var required = []interface{}{"_id", "address", "age", "code", "name", "score", "status", "tags"}
+	if reflect.DeepEqual(schema["required"], required) == false {
+		t.Errorf("expected required %v, got %v", required, schema["required"])
+	}
+
+	var properties = schema["properties"].(map[string]interface{})
+	var tests = []struct {
+		key      string
+		expected map[string]interface{}
+	}{
+		{"_id", map[string]interface{}{"bsonType": "objectId"}},
+		{"name", map[string]interface{}{"bsonType": "string"}},
+		{"status", map[string]interface{}{"bsonType": "string", "enum": []interface{}{"off", "on"}}},
+		{"age", map[string]interface{}{"bsonType": []interface{}{"int", "long"}, "minimum": int64(18), "maximum": int64(1) << 40}},
+		{"code", map[string]interface{}{"bsonType": []interface{}{"int", "string"}, "minimum": int64(10), "maximum": int64(10)}},
+		{"nick", map[string]interface{}{"bsonType": []interface{}{"null", "string"}}},
+		{"price", map[string]interface{}{"bsonType": "decimal", "minimum": 0.001, "maximum": 10.25}},
+		{"score", map[string]interface{}{"bsonType": []interface{}{"double", "int"}, "minimum": -1.25, "maximum": float64(10)}},
+		{"createdAt", map[string]interface{}{"bsonType": "date"}},
+		{"address", map[string]interface{}{
+			"bsonType": "object",
+			"required": []interface{}{"street"},
+			"properties": map[string]interface{}{
+				"street": map[string]interface{}{"bsonType": "string"},
+				"number": map[string]interface{}{"bsonType": "int", "minimum": int64(10), "maximum": int64(10)},
+			},
+		}},
+		{"tags", map[string]interface{}{
+			"bsonType": "array",
+			"items":    map[string]interface{}{"bsonType": "string", "enum": []interface{}{"a", "b"}},
+		}},
+	}
+
+	if len(properties) != len(tests) {
+		t.Errorf("expected %v properties, got %v", len(tests), properties)
+	}
+
+	for _, test := range tests {
+		if reflect.DeepEqual(properties[test.key], test.expected) == false {
+			t.Errorf("%v: expected %v, got %v", test.key, test.expected, properties[test.key])
+		}
+	}
+
+	// (English): the proposed schema accepts all samples
+	//
+	// (Português): o esquema proposto aceita todas as amostras
+	var populated *MongoDBJsonSchema
+	populated, err = inference.GetMongoDBJsonSchema()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	for _, sample := range schemaInferenceTestSampleList(t) {
+		var result = populated.Validate(sample)
+		if result.Valid() == false {
+			t.Errorf("%T: unexpected violations: %v", sample, result.Errors())
+		}
+	}
+}
+
+func TestSchemaInference_GetStatistics(t *testing.T) {
+	var inference = SchemaInference{EnumMaxValues: 2}
+	for _, sample := range schemaInferenceTestSampleList(t) {
+		if err := inference.Add(sample); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	var statisticsList = inference.GetStatistics()
+	var pathList = make([]string, 0)
+	var statisticsByPath = make(map[string]FieldStatistics)
+	for _, statistics := range statisticsList {
+		pathList = append(pathList, statistics.Path)
+		statisticsByPath[statistics.Path] = statistics
+	}
+
+	// (English): the keys of the first sample, a map, are sorted
+	//
+	// (Português): as chaves da primeira amostra, um mapa, são ordenadas
+	var expectedPathList = []string{
+		"_id", "address", "address.number", "address.street", "age", "code", "name", "nick", "price",
+		"score", "status", "tags", "tags.[]", "createdAt",
+	}
+	if reflect.DeepEqual(pathList, expectedPathList) == false {
+		t.Errorf("expected %v, got %v", expectedPathList, pathList)
+	}
+
+	var nick = statisticsByPath["nick"]
+	if nick.Count != 2 || nick.ParentCount != 3 || nick.Frequency != 2.0/3.0 {
+		t.Errorf("unexpected nick: %+v", nick)
+	}
+
+	if reflect.DeepEqual(nick.Types, map[string]int64{"null": 1, "string": 1}) == false {
+		t.Errorf("unexpected nick types: %v", nick.Types)
+	}
+
+	var age = statisticsByPath["age"]
+	if age.Minimum.Cmp(big.NewRat(18, 1)) != 0 || age.Maximum.Cmp(new(big.Rat).SetInt64(1<<40)) != 0 {
+		t.Errorf("unexpected age: %v %v", age.Minimum, age.Maximum)
+	}
+
+	var tags = statisticsByPath["tags.[]"]
+	if tags.Count != 3 || tags.ParentCount != 3 || reflect.DeepEqual(tags.StringValues, map[string]int64{"a": 2, "b": 1}) == false {
+		t.Errorf("unexpected tags: %+v", tags)
+	}
+
+	// (English): more than EnumMaxValues distinct strings
+	//
+	// (Português): mais de EnumMaxValues strings distintas
+	if statisticsByPath["name"].StringValues != nil {
+		t.Errorf("unexpected name: %+v", statisticsByPath["name"])
+	}
+
+	var addressNumber = statisticsByPath["address.number"]
+	if addressNumber.Count != 1 || addressNumber.ParentCount != 3 {
+		t.Errorf("unexpected address.number: %+v", addressNumber)
+	}
+}
+
+func TestSchemaInference_GetStatisticsItems(t *testing.T) {
+	var inference = SchemaInference{}
+	for _, sample := range []interface{}{
+		bson.D{{Key: "friends", Value: bson.A{
+			bson.D{{Key: "name", Value: "Ana"}, {Key: "age", Value: 20}},
+			bson.D{{Key: "name", Value: "Bruno"}},
+			"Carla",
+		}}},
+		bson.D{{Key: "friends", Value: bson.A{}}},
+		bson.D{{Key: "friends", Value: bson.A{bson.D{{Key: "name", Value: "Dino"}}}}},
+	} {
+		if err := inference.Add(sample); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	var tests = []FieldStatistics{
+		{Path: "friends", Count: 3, ParentCount: 3, Frequency: 1},
+		{Path: "friends.[]", Count: 4, ParentCount: 3, Frequency: 4.0 / 3.0},
+		{Path: "friends.[].name", Count: 3, ParentCount: 4, Frequency: 0.75},
+		{Path: "friends.[].age", Count: 1, ParentCount: 4, Frequency: 0.25},
+	}
+
+	var statisticsList = inference.GetStatistics()
+	if len(statisticsList) != len(tests) {
+		t.Fatalf("expected %v statistics, got %+v", len(tests), statisticsList)
+	}
+
+	for k, test := range tests {
+		var statistics = statisticsList[k]
+		if statistics.Path != test.Path || statistics.Count != test.Count || statistics.ParentCount != test.ParentCount || statistics.Frequency != test.Frequency {
+			t.Errorf("expected %+v, got %+v", test, statistics)
+		}
+	}
+
+	var items = inference.GetSchema()["properties"].(map[string]interface{})["friends"].(map[string]interface{})["items"].(map[string]interface{})
+	if reflect.DeepEqual(items["bsonType"], []interface{}{"object", "string"}) == false || reflect.DeepEqual(items["required"], []interface{}{"name"}) == false {
+		t.Errorf("unexpected items: %v", items)
+	}
+}
+
+func TestSchemaInference_GetSchemaDecimalBounds(t *testing.T) {
+	var err error
+	var inference = SchemaInference{DisableEnum: true}
+	var sampleList []interface{}
+	for _, text := range []string{"0.1000000000000000000000000001", "0.3000000000000000000000000001"} {
+		var number, _ = primitive.ParseDecimal128(text)
+		sampleList = append(sampleList, bson.D{{Key: "price", Value: number}})
+	}
+
+	var huge, _ = primitive.ParseDecimal128("1E+400")
+	for _, sample := range append(sampleList, bson.D{{Key: "huge", Value: huge}}) {
+		err = inference.Add(sample)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	var properties = inference.GetSchema()["properties"].(map[string]interface{})
+	var price = properties["price"].(map[string]interface{})
+	if _, ok := price["minimum"].(float64); ok == false {
+		t.Fatalf("expected numeric bounds, got %v", price)
+	}
+
+	if _, ok := properties["huge"].(map[string]interface{})["maximum"]; ok == true {
+		t.Errorf("expected no bounds out of float64 range, got %v", properties["huge"])
+	}
+
+	var populated *MongoDBJsonSchema
+	populated, err = inference.GetMongoDBJsonSchema()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	for _, sample := range sampleList {
+		var result = populated.Validate(sample)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", sample, result.Errors())
+		}
+	}
+}
+
+func TestSchemaInference_AddNDJSON(t *testing.T) {
+	var err error
+	var inference = SchemaInference{DisableEnum: true}
+	err = inference.AddNDJSON(strings.NewReader(`{"_id":{"$oid":"5f8f8c8b8b8b8b8b8b8b8b8b"},"count":{"$numberLong":"5"},"date":{"$date":"2020-10-21T00:00:00Z"}}
+
+{"_id":{"$oid":"5f8f8c8b8b8b8b8b8b8b8b8c"},"count":{"$numberInt":"7"},"name":"Ana"}
+{"_id":{"$oid":"5f8f8c8b8b8b8b8b8b8b8b8d"},"count":1.5,"name":"Ana"}`))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var schema = inference.GetSchema()
+	var expected = map[string]interface{}{
+		"bsonType": "object",
+		"required": []interface{}{"_id", "count"},
+		"properties": map[string]interface{}{
+			"_id":   map[string]interface{}{"bsonType": "objectId"},
+			"count": map[string]interface{}{"bsonType": []interface{}{"double", "int", "long"}, "minimum": 1.5, "maximum": float64(7)},
+			"date":  map[string]interface{}{"bsonType": "date"},
+			"name":  map[string]interface{}{"bsonType": "string"},
+		},
+	}
+	if reflect.DeepEqual(schema, expected) == false {
+		t.Errorf("expected %v, got %v", expected, schema)
+	}
+
+	err = inference.AddNDJSON(strings.NewReader("{\"a\":1}\n{\"a\":\n"))
+	if err == nil || strings.HasPrefix(err.Error(), "line 2: ") == false {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSchemaInference_AddBSON(t *testing.T) {
+	var err error
+	var dump bytes.Buffer
+	for _, document := range []bson.D{
+		{{Key: "name", Value: "Ana"}, {Key: "age", Value: int32(10)}},
+		{{Key: "name", Value: "Bruno"}},
+	} {
+		var data []byte
+		data, err = bson.Marshal(document)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		dump.Write(data)
+	}
+
+	var inference = SchemaInference{}
+	err = inference.AddBSON(&dump)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var schema = inference.GetSchema()
+	if reflect.DeepEqual(schema["required"], []interface{}{"name"}) == false {
+		t.Errorf("unexpected schema: %v", schema)
+	}
+
+	var statisticsList = inference.GetStatistics()
+	if len(statisticsList) != 2 || statisticsList[1].Path != "age" || statisticsList[1].Count != 1 {
+		t.Errorf("unexpected statistics: %+v", statisticsList)
+	}
+
+	if inference.Add("not a document") == nil {
+		t.Errorf("a string must return an error")
+	}
+
+	var empty = SchemaInference{}
+	if empty.GetSchema() != nil {
+		t.Errorf("a schema without samples must be nil")
+	}
+
+	if _, err = empty.GetMongoDBJsonSchema(); err == nil {
+		t.Errorf("a schema without samples must return an error")
+	}
+}
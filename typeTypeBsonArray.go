@@ -27,7 +27,12 @@ type TypeBsonArray struct {
 	TypeBsonCommonToAllTypes
 
 	// A schema for all array items, or an array of schemas where order matters.
-	Items map[string]map[string]BsonType
+	// Rules of the items schema document, one for each bsonType.
+	Items map[string]BsonType
+
+	// Array of schemas where order matters: the item of position n must be valid against
+	// the schema of position n.
+	ItemsTuple []map[string]BsonType
 
 	// Default: true.
 	// If true, the array may contain additional values that are not defined in the schema.
@@ -42,10 +47,12 @@ type TypeBsonArray struct {
 	// effect.
 	AdditionalItemsBoolIsSet bool
 	AdditionalItemsBoolValue bool
-	AdditionalItemsMap       map[string]map[string]BsonType
+	// rules of the schema document, one for each bsonType
+	AdditionalItemsMap map[string]BsonType
 
 	// The maximum length of the array.
-	MaxItems int64
+	MaxItems       int64
+	MaxItemsHasSet bool
 
 	// The minimum length of the array.
 	MinItems       int64
@@ -58,13 +65,18 @@ type TypeBsonArray struct {
 }
 
 func (el *TypeBsonArray) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("array", value)
+	if err != nil {
+		return
+	}
+
 	err = el.verifyParent(value)
 	if err != nil {
 		return
 	}
 
 	if el.Enum.values != nil {
-		err = errors.New("enum is not compatible with the bson array type")
+		err = newViolation("enum", el.Enum.values, value, "enum is not compatible with the bson array type")
 		return
 	}
 
@@ -83,12 +95,91 @@ func (el *TypeBsonArray) Verify(value interface{}) (err error) {
 		return
 	}
 
+	err = el.VerifyUniqueItems(value)
+	if err != nil {
+		return
+	}
+
 	err = el.verifyItems(value)
 	return
 }
 
+// validate (English): Validates the array and each of its items, collecting every
+// violation found in result
+//
+// validate (Português): Valida o array e cada um dos seus itens, coletando todas as
+// violações encontradas em result
+func (el *TypeBsonArray) validate(path string, value interface{}, result *ValidationResult) {
+	var err error
+
+	err = el.verifyNotNull("array", value)
+	if err != nil {
+		result.appendError(path, value, err)
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		result.appendError(path, value, err)
+		return
+	}
+
+	if el.Enum.values != nil {
+		result.appendError(path, value, newViolation("enum", el.Enum.values, value, "enum is not compatible with the bson array type"))
+		return
+	}
+
+	err = el.VerifyType(value)
+	if err != nil {
+		result.appendError(path, value, err)
+		return
+	}
+
+	result.appendError(path, value, el.VerifyMaxItems(value))
+	result.appendError(path, value, el.VerifyMinItems(value))
+	el.validateUniqueItems(path, value, result)
+
+	list, _ := el.parentConvertInterfaceToSlice(value)
+	el.validateItems(path, list, result)
+}
+
+// validateItems (English): Validates each item against 'items'. In the tuple form, the
+// items after the last schema are verified by 'additionalItems'.
+//
+// validateItems (Português): Valida cada item contra 'items'. Na forma de tupla, os itens
+// depois do último esquema são verificados por 'additionalItems'.
+func (el *TypeBsonArray) validateItems(path string, list []interface{}, result *ValidationResult) {
+	for index, item := range list {
+		var itemPath = joinPath(path, strconv.Itoa(index))
+
+		if el.ItemsTuple == nil {
+			el.validateProperty(itemPath, el.Items, item, result)
+			continue
+		}
+
+		if index < len(el.ItemsTuple) {
+			el.validateProperty(itemPath, el.ItemsTuple[index], item, result)
+			continue
+		}
+
+		if el.AdditionalItemsBoolIsSet == true && el.AdditionalItemsBoolValue == false {
+			result.append(Violation{
+				Path:     itemPath,
+				Keyword:  "additionalItems",
+				Expected: len(el.ItemsTuple),
+				Actual:   item,
+				Message:  "the array must have at most " + strconv.Itoa(len(el.ItemsTuple)) + " items",
+			})
+			continue
+		}
+
+		el.validateProperty(itemPath, el.AdditionalItemsMap, item, result)
+	}
+}
+
 func (el *TypeBsonArray) VerifyType(value interface{}) (err error) {
-	if value == nil && el.Enum.values == nil {
+	err = el.verifyNotNull("array", value)
+	if err != nil {
 		return
 	}
 
@@ -98,6 +189,9 @@ func (el *TypeBsonArray) VerifyType(value interface{}) (err error) {
 	}
 
 	err = el.parentVerifyInterfaceTypeIsArray(value)
+	if err != nil {
+		err = newViolation("bsonType", "array", value, err.Error())
+	}
 	return
 }
 
@@ -106,19 +200,18 @@ func (el *TypeBsonArray) VerifyMaxItems(value interface{}) (err error) {
 		return
 	}
 
-	if el.MaxItems == 0 {
+	if el.MaxItemsHasSet == false {
 		return
 	}
 
-	switch converted := value.(type) {
-	case nil:
-	case map[string]interface{}:
-		if len(converted) > int(el.MaxItems) {
-			err = errors.New("the maximum number of items must be respected")
-			return
-		}
-	default:
-		err = errors.New("wrong type. value must be a map[string]interface{}")
+	converted, err := el.parentConvertInterfaceToSlice(value)
+	if err != nil {
+		err = newViolation("bsonType", "array", value, "wrong type. value must be a array")
+		return
+	}
+
+	if len(converted) > int(el.MaxItems) {
+		err = newViolation("maxItems", el.MaxItems, len(converted), "the maximum number of items must be respected")
 	}
 
 	return
@@ -133,15 +226,14 @@ func (el *TypeBsonArray) VerifyMinItems(value interface{}) (err error) {
 		return
 	}
 
-	switch converted := value.(type) {
-	case nil:
-	case map[string]interface{}:
-		if int(el.MinItems) > len(converted) {
-			err = errors.New("the minimum number of items must be respected")
-			return
-		}
-	default:
-		err = errors.New("wrong type. value must be a map[string]interface{}")
+	converted, err := el.parentConvertInterfaceToSlice(value)
+	if err != nil {
+		err = newViolation("bsonType", "array", value, "wrong type. value must be a array")
+		return
+	}
+
+	if int(el.MinItems) > len(converted) {
+		err = newViolation("minItems", el.MinItems, len(converted), "the minimum number of items must be respected")
 	}
 
 	return
@@ -152,18 +244,16 @@ func (el *TypeBsonArray) verifyItems(value interface{}) (err error) {
 		return
 	}
 
-	var element MongoDBJsonSchema
+	list, err := el.parentConvertInterfaceToSlice(value)
+	if err != nil {
+		err = newViolation("bsonType", "array", value, "wrong type. value must be a array")
+		return
+	}
 
-	switch value.(type) {
-	case []map[string]interface{}:
-		for _, dataItemValue := range value.([]map[string]interface{}) {
-			err = element.VerifyDocumentByProperties(&el.Items, dataItemValue)
-			if err != nil {
-				return
-			}
-		}
-	default:
-		err = errors.New("value must be a map[string]interface{}")
+	var result ValidationResult
+	el.validateItems("", list, &result)
+	if result.Valid() == false {
+		err = result.Violations[0]
 	}
 
 	return
@@ -184,7 +274,7 @@ func (el *TypeBsonArray) Populate(schema map[string]interface{}) (err error) {
 	//  return
 	//}
 
-	el.MaxItems, err = el.getPropertyMaxItems(schema)
+	el.MaxItemsHasSet, el.MaxItems, err = el.getPropertyMaxItems(schema)
 	if err != nil {
 		return
 	}
@@ -199,7 +289,7 @@ func (el *TypeBsonArray) Populate(schema map[string]interface{}) (err error) {
 		return
 	}
 
-	el.Items, err = el.PopulateItens(schema)
+	el.Items, el.ItemsTuple, err = el.PopulateItens(schema)
 	if err != nil {
 		return
 	}
@@ -212,54 +302,59 @@ func (el *TypeBsonArray) Populate(schema map[string]interface{}) (err error) {
 	return
 }
 
-func (el *TypeBsonArray) PopulateItens(schema map[string]interface{}) (items map[string]map[string]BsonType, err error) {
+// PopulateItens (English): Populates 'items' as a single schema document, used by all
+// items, or as an array of schema documents, where order matters
+//
+// PopulateItens (Português): Popula 'items' como um único documento de esquema, usado
+// por todos os itens, ou como um array de documentos de esquema, onde a ordem importa
+func (el *TypeBsonArray) PopulateItens(schema map[string]interface{}) (items map[string]BsonType, itemsTuple []map[string]BsonType, err error) {
 
 	var found bool
 
-	_, found = schema["items"].(map[string]interface{})
+	_, found = schema["items"]
 	if found == false {
 		return
 	}
 
-	items = make(map[string]map[string]BsonType)
-
-	var key string
 	var newSchemaMap = make(map[string]interface{})
-	var newSchemaArray = make([]interface{}, 0)
 	var element MongoDBJsonSchema
+	var object TypeBsonObject
+	object.setParseOptions(el.options)
 
-	switch schema["items"].(type) {
+	switch converted := schema["items"].(type) {
 	case []interface{}:
-		newSchemaArray, _ = schema["items"].([]interface{})
-		_ = newSchemaArray
-	case map[string]interface{}:
-		newSchemaMap = element.filterSchemaElements(schema["items"].(map[string]interface{}))
-		newSchemaMap, found = newSchemaMap["properties"].(map[string]interface{})
-		if found == false {
-			err = errors.New("bsonType 'array' has key 'items', but not has key 'items.properties'")
-			return
-		}
+		itemsTuple = make([]map[string]BsonType, 0, len(converted))
+		for index, itemSchema := range converted {
+			newSchemaMap, found = itemSchema.(map[string]interface{})
+			if found == false {
+				err = errors.New("items." + strconv.Itoa(index) + ": value must be a schema document")
+				return
+			}
 
-		for schemaCellKey, schemaCell := range newSchemaMap {
-			var typesInCell []string
-			typesInCell, err = element.getPropertyBsonType(schemaCell.(map[string]interface{}))
-
-			for _, currentType := range typesInCell {
-				if key != "" {
-					schemaCellKey = key + "." + schemaCellKey
-				}
-				err = element.typeStringToTypeObjectPopulated(&items, schemaCellKey, currentType, schemaCell.(map[string]interface{}))
-				if err != nil {
-					return
-				}
+			var alternatives map[string]BsonType
+			alternatives, err = object.populateAlternatives(element.filterSchemaElements(newSchemaMap))
+			if err != nil {
+				err = errors.New("items." + strconv.Itoa(index) + ": " + err.Error())
+				return
 			}
+
+			itemsTuple = append(itemsTuple, alternatives)
+		}
+	case map[string]interface{}:
+		newSchemaMap = element.filterSchemaElements(converted)
+		items, err = object.populateAlternatives(newSchemaMap)
+		if err != nil {
+			err = errors.New("items: " + err.Error())
+			return
 		}
+	default:
+		err = errors.New("'items' key must be a schema document or a array of schema documents")
 	}
 
 	return
 }
 
-func (el *TypeBsonArray) getPropertyMaxItems(schema map[string]interface{}) (maxItems int64, err error) {
+func (el *TypeBsonArray) getPropertyMaxItems(schema map[string]interface{}) (set bool, maxItems int64, err error) {
 	var found bool
 
 	_, found = schema["maxItems"]
@@ -267,6 +362,7 @@ func (el *TypeBsonArray) getPropertyMaxItems(schema map[string]interface{}) (max
 		return
 	}
 
+	set = true
 	maxItems, err = el.getPropertyAsInt64(schema, "maxItems")
 	return
 }
@@ -296,20 +392,16 @@ func (el *TypeBsonArray) getPropertyUniqueItems(schema map[string]interface{}) (
 	return
 }
 
-// AdditionalItemsBoolIsSet bool
-// AdditionalItemsBoolValue bool
-// AdditionalItemsMap map[string]map[string]BsonType
-func (el *TypeBsonArray) getPropertyAdditionalItens(schema map[string]interface{}) (boolIsSet bool, boolValue bool, itemsMap map[string]map[string]BsonType, err error) {
+// getPropertyAdditionalItens (English): Populates 'additionalItems' in the boolean form
+// or in the schema document form
+//
+// getPropertyAdditionalItens (Português): Popula 'additionalItems' na forma booleana ou
+// na forma de documento de esquema
+func (el *TypeBsonArray) getPropertyAdditionalItens(schema map[string]interface{}) (boolIsSet bool, boolValue bool, itemsMap map[string]BsonType, err error) {
 	var found bool
-
-	_, found = schema["additionalProperties"]
-	if found == false {
-		return
-	}
-
 	var value interface{}
 
-	value, found = schema["additionalProperties"]
+	value, found = schema["additionalItems"]
 	if found == false {
 		return
 	}
@@ -326,33 +418,15 @@ func (el *TypeBsonArray) getPropertyAdditionalItens(schema map[string]interface{
 		}
 		return
 	case map[string]interface{}:
-		boolValue = false
-		boolIsSet = false
-
-		var newSchemaMap = make(map[string]interface{})
 		var element MongoDBJsonSchema
-		var key string
-		newSchemaMap = element.filterSchemaElements(schema["additionalProperties"].(map[string]interface{})["items"].(map[string]interface{}))
-		newSchemaMap, found = newSchemaMap["properties"].(map[string]interface{})
-		if found == false {
-			err = errors.New("bsonType 'array' has key 'additionalProperties', but not has key 'additionalProperties.properties'")
-			return
-		}
-
-		for schemaCellKey, schemaCell := range newSchemaMap {
-			var typesInCell []string
-			typesInCell, err = element.getPropertyBsonType(schemaCell.(map[string]interface{}))
-
-			for _, currentType := range typesInCell {
-				if key != "" {
-					schemaCellKey = key + "." + schemaCellKey
-				}
-				err = element.typeStringToTypeObjectPopulated(&itemsMap, schemaCellKey, currentType, schemaCell.(map[string]interface{}))
-				if err != nil {
-					return
-				}
-			}
+		var object TypeBsonObject
+		object.setParseOptions(el.options)
+		itemsMap, err = object.populateAlternatives(element.filterSchemaElements(converted))
+		if err != nil {
+			err = errors.New("additionalItems: " + err.Error())
 		}
+	default:
+		err = errors.New("'additionalItems' key must be a boolean or a schema document")
 	}
 
 	return
@@ -0,0 +1,214 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTypeBsonArray_ItemsTuple(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "point": {
+        "bsonType": "array",
+        "items": [
+          { "bsonType": "double", "minimum": -180, "maximum": 180 },
+          { "bsonType": "double", "minimum": -90, "maximum": 90 }
+        ],
+        "additionalItems": false
+      },
+      "tagged": {
+        "bsonType": "array",
+        "items": [
+          { "bsonType": "string" }
+        ],
+        "additionalItems": { "bsonType": "int" }
+      },
+      "free": {
+        "bsonType": "array",
+        "items": [
+          { "bsonType": "string" }
+        ],
+        "additionalItems": true
+      }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if len(schema.Properties["point"]["array"].ElementType.(*TypeBsonArray).ItemsTuple) != 2 {
+		t.Fatalf("unexpected items: %#v", schema.Properties["point"]["array"].ElementType)
+	}
+
+	var validList = []map[string]interface{}{
+		{"point": []interface{}{-46.6, -23.5}},
+		{"point": []interface{}{-46.6}},
+		{"tagged": []interface{}{"id", 1, 2, 3}},
+		{"free": []interface{}{"id", 1, "two", 3.0}},
+	}
+
+	for _, document := range validList {
+		var result = schema.Validate(document)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", document, result.Errors())
+		}
+	}
+
+	var result = schema.Validate(map[string]interface{}{
+		"point":  []interface{}{-46.6, -100.0, 0.0},
+		"tagged": []interface{}{1, 2, "three"},
+	})
+
+	var tests = []struct {
+		path    string
+		keyword string
+	}{
+		{"point.1", "minimum"},
+		{"point.2", "additionalItems"},
+		{"tagged.0", "bsonType"},
+		{"tagged.2", "bsonType"},
+	}
+
+	for _, test := range tests {
+		if _, found := validateTestFind(result, test.path, test.keyword); found == false {
+			t.Errorf("violation %v (%v) not found: %v", test.path, test.keyword, result.Errors())
+		}
+	}
+
+	if len(result.Violations) != len(tests) {
+		t.Errorf("expected %v violations, got: %v", len(tests), result.Errors())
+	}
+}
+
+func TestTypeBsonArray_ItemsAdditionalItemsIgnored(t *testing.T) {
+	var schema = MongoDBJsonSchema{}
+	var err = schema.UnmarshalJSON([]byte(`
+  {
+    "properties": {
+      "list": {
+        "bsonType": "array",
+        "items": { "bsonType": "string" },
+        "additionalItems": false
+      }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var result = schema.Validate(map[string]interface{}{"list": []interface{}{"a", "b", "c"}})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+}
+
+func TestTypeBsonArray_ItemsPopulateError(t *testing.T) {
+	var schemaList = []string{
+		`{"properties": {"a": {"bsonType": "array", "items": ["string"]}}}`,
+		`{"properties": {"a": {"bsonType": "array", "items": "string"}}}`,
+		`{"properties": {"a": {"bsonType": "array", "items": [{"bsonType": "text"}]}}}`,
+		`{"properties": {"a": {"bsonType": "array", "items": [], "additionalItems": 1}}}`,
+	}
+
+	for _, jsonSchema := range schemaList {
+		var schema = MongoDBJsonSchema{}
+		if schema.UnmarshalJSON([]byte(jsonSchema)) == nil {
+			t.Errorf("%v: error expected", jsonSchema)
+		}
+	}
+}
+
+func TestTypeBsonArray_ItemsScalarAndNested(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "tags": {
+        "bsonType": "array",
+        "maxItems": 3,
+        "items": { "bsonType": "string", "maxLength": 5 }
+      },
+      "scores": {
+        "bsonType": "array",
+        "items": { "bsonType": ["int", "long", "double"], "minimum": 0 }
+      },
+      "refs": {
+        "bsonType": "array",
+        "items": { "bsonType": "objectId" }
+      },
+      "matrix": {
+        "bsonType": "array",
+        "items": {
+          "bsonType": "array",
+          "minItems": 2,
+          "items": { "bsonType": "double" }
+        }
+      }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var validList = []map[string]interface{}{
+		{"tags": []interface{}{"a", "bc"}},
+		{"tags": bson.A{"a", "bc"}},
+		{"tags": []string{"a", "bc", "def"}},
+		{"scores": []int{1, 2}},
+		{"scores": []interface{}{int32(1), int64(2), 3.5}},
+		{"refs": []primitive.ObjectID{primitive.NewObjectID()}},
+		{"matrix": [][]float64{{1, 2}, {3, 4}}},
+		{"matrix": bson.A{bson.A{1.0, 2.0}, []interface{}{3.0, 4.0}}},
+	}
+
+	for _, document := range validList {
+		var result = schema.Validate(document)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", document, result.Errors())
+		}
+	}
+
+	var result = schema.Validate(map[string]interface{}{
+		"tags":   []string{"a", "abcdef", "b", "c"},
+		"scores": bson.A{1, -1},
+		"refs":   []interface{}{"5f7c4b1e2d9a8b0001a1b2c3"},
+		"matrix": [][]float64{{1, 2}, {3}},
+	})
+
+	var tests = []struct {
+		path    string
+		keyword string
+	}{
+		{"matrix.1", "minItems"},
+		{"refs.0", "bsonType"},
+		{"scores.1", "minimum"},
+		{"tags", "maxItems"},
+		{"tags.1", "maxLength"},
+	}
+
+	for _, test := range tests {
+		if _, found := validateTestFind(result, test.path, test.keyword); found == false {
+			t.Errorf("violation %v (%v) not found: %v", test.path, test.keyword, result.Errors())
+		}
+	}
+
+	if len(result.Violations) != len(tests) {
+		t.Errorf("expected %v violations, got: %v", len(tests), result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{"tags": []byte("abc")})
+	if len(result.Filter("bsonType")) != 1 {
+		t.Errorf("[]byte must not be an array: %v", result.Errors())
+	}
+}
@@ -0,0 +1,53 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"strconv"
+)
+
+// VerifyUniqueItems (English): Verifies that all items of the array are unique, using
+// the BSON comparison rules
+//
+// VerifyUniqueItems (Português): Verifica se todos os itens do array são únicos, usando
+// as regras de comparação do BSON
+func (el *TypeBsonArray) VerifyUniqueItems(value interface{}) (err error) {
+	var result ValidationResult
+	el.validateUniqueItems("", value, &result)
+	if result.Valid() == false {
+		err = result.Violations[0]
+	}
+
+	return
+}
+
+// validateUniqueItems (English): Adds one violation for each item equal to a previous
+// item. Actual holds the indexes of the first item and of the duplicated item.
+//
+// validateUniqueItems (Português): Adiciona uma violação para cada item igual a um item
+// anterior. Actual contém os índices do primeiro item e do item duplicado.
+func (el *TypeBsonArray) validateUniqueItems(path string, value interface{}, result *ValidationResult) {
+	if el.UniqueItems == false || value == nil {
+		return
+	}
+
+	list, err := el.parentConvertInterfaceToSlice(value)
+	if err != nil {
+		return
+	}
+
+	for index := 1; index < len(list); index += 1 {
+		for previous := 0; previous < index; previous += 1 {
+			if el.parentCompareValues(list[previous], list[index]) == false {
+				continue
+			}
+
+			result.append(Violation{
+				Path:     path,
+				Keyword:  "uniqueItems",
+				Expected: true,
+				Actual:   []int{previous, index},
+				Message:  "the items " + strconv.Itoa(previous) + " and " + strconv.Itoa(index) + " are equal, items must be unique",
+			})
+			break
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTypeBsonArray_VerifyUniqueItems(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "properties": {
+      "list": { "bsonType": "array", "uniqueItems": true }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var decimal, _ = primitive.ParseDecimal128("1.5E+1")
+	var id = primitive.NewObjectID()
+	var now = time.Now()
+
+	var tests = []struct {
+		list   interface{}
+		first  int
+		second int
+	}{
+		{[]interface{}{1, "1", true}, -1, -1},
+		{[]interface{}{1, int64(2), 1.0}, 0, 2},
+		{[]interface{}{int32(15), "a", decimal}, 0, 2},
+		{[]interface{}{0.1, 0.2, 0.30000000000000004, 0.3}, -1, -1},
+		{bson.A{id, primitive.NewObjectID(), id}, 0, 2},
+		{[]interface{}{now, primitive.NewDateTimeFromTime(now)}, 0, 1},
+		{[]interface{}{map[string]interface{}{"a": 1, "b": bson.A{1, 2}}, bson.M{"b": []int{1, 2}, "a": 1.0}}, 0, 1},
+		{[]interface{}{bson.A{1, 2}, bson.A{2, 1}}, -1, -1},
+		{bson.A{bson.D{{Key: "x", Value: 1}, {Key: "y", Value: 2}}, bson.D{{Key: "y", Value: 2}, {Key: "x", Value: 1}}}, -1, -1},
+		{bson.A{bson.D{{Key: "x", Value: 1}, {Key: "y", Value: 2}}, bson.D{{Key: "x", Value: 1.0}, {Key: "y", Value: int64(2)}}}, 0, 1},
+		{bson.A{bson.D{{Key: "a", Value: 1}, {Key: "b", Value: 2}}, bson.M{"b": 2, "a": 1}}, 0, 1},
+		{[]interface{}{map[string]interface{}{"a": 1}, map[string]interface{}{"a": 1, "b": nil}}, -1, -1},
+		{[]string{"a", "b", "c", "b"}, 1, 3},
+		{[]interface{}{nil, false, nil}, 0, 2},
+	}
+
+	for _, test := range tests {
+		var result = schema.Validate(map[string]interface{}{"list": test.list})
+		if test.first == -1 {
+			if result.Valid() == false {
+				t.Errorf("%v: unexpected violations: %v", test.list, result.Errors())
+			}
+			continue
+		}
+
+		if len(result.Violations) != 1 || result.Violations[0].Keyword != "uniqueItems" || result.Violations[0].Path != "list" {
+			t.Errorf("%v: expected 1 violation, got: %v", test.list, result.Errors())
+			continue
+		}
+
+		var indexList = result.Violations[0].Actual.([]int)
+		if indexList[0] != test.first || indexList[1] != test.second {
+			t.Errorf("%v: unexpected indexes: %v", test.list, indexList)
+		}
+	}
+
+	var result = schema.Validate(map[string]interface{}{"list": []int{1, 1, 1}})
+	if len(result.Violations) != 2 || result.Violations[1].Error() != "list: the items 0 and 2 are equal, items must be unique" {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+}
@@ -0,0 +1,216 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"errors"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The binData schema type accepts primitive.Binary and []byte values. A []byte value
+// has the generic binary subtype, 0.
+//
+// The keys 'binDataSubType', 'binDataMinLength' and 'binDataMaxLength' are extensions
+// of this module and are not part of the MongoDB $jsonSchema.
+//
+//   Example:
+//   {
+//     "bsonType": "binData",
+//     "binDataSubType": [ 4 ],
+//     "binDataMinLength": 16,
+//     "binDataMaxLength": 16
+//   }
+type TypeBsonBinData struct {
+	TypeBsonCommonToAllTypes
+
+	// List of accepted binary subtypes. When empty, all subtypes are accepted.
+	SubType []byte
+
+	// The minimum number of bytes of the binary data.
+	MinLength       int64
+	MinLengthHasSet bool
+
+	// The maximum number of bytes of the binary data.
+	MaxLength       int64
+	MaxLengthHasSet bool
+}
+
+func (el *TypeBsonBinData) getTypeString() string {
+	return "binData"
+}
+
+func (el *TypeBsonBinData) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("binData", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifySubType(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyMinLength(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyMaxLength(value)
+	return
+}
+
+func (el *TypeBsonBinData) VerifyType(value interface{}) (err error) {
+	switch value.(type) {
+	case primitive.Binary:
+	case []byte:
+	default:
+		err = newViolation("bsonType", "binData", value, "wrong type")
+	}
+
+	return
+}
+
+func (el *TypeBsonBinData) VerifySubType(value interface{}) (err error) {
+	if value == nil || len(el.SubType) == 0 {
+		return
+	}
+
+	var subType, _ = el.convertInterfaceToBinary(value)
+	for _, accepted := range el.SubType {
+		if accepted == subType {
+			return
+		}
+	}
+
+	err = newViolation("binDataSubType", el.SubType, subType, "the binary subtype "+strconv.Itoa(int(subType))+" is not allowed")
+	return
+}
+
+func (el *TypeBsonBinData) VerifyMinLength(value interface{}) (err error) {
+	if value == nil || el.MinLengthHasSet == false {
+		return
+	}
+
+	var _, data = el.convertInterfaceToBinary(value)
+	if int64(len(data)) < el.MinLength {
+		err = newViolation("binDataMinLength", el.MinLength, len(data), "minimum binary length expected")
+	}
+
+	return
+}
+
+func (el *TypeBsonBinData) VerifyMaxLength(value interface{}) (err error) {
+	if value == nil || el.MaxLengthHasSet == false {
+		return
+	}
+
+	var _, data = el.convertInterfaceToBinary(value)
+	if int64(len(data)) > el.MaxLength {
+		err = newViolation("binDataMaxLength", el.MaxLength, len(data), "maximum binary length exceeded")
+	}
+
+	return
+}
+
+// convertInterfaceToBinary (English): Returns the subtype and the data of primitive.Binary
+// and []byte values
+//
+// convertInterfaceToBinary (Português): Retorna o subtipo e o dado de valores
+// primitive.Binary e []byte
+func (el *TypeBsonBinData) convertInterfaceToBinary(value interface{}) (subType byte, data []byte) {
+	switch converted := value.(type) {
+	case primitive.Binary:
+		subType = converted.Subtype
+		data = converted.Data
+	case []byte:
+		data = converted
+	}
+
+	return
+}
+
+func (el *TypeBsonBinData) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	if err != nil {
+		return
+	}
+
+	el.SubType, err = el.getPropertySubType(schema)
+	if err != nil {
+		return
+	}
+
+	el.MinLengthHasSet, el.MinLength, err = el.getPropertyLength(schema, "binDataMinLength")
+	if err != nil {
+		return
+	}
+
+	el.MaxLengthHasSet, el.MaxLength, err = el.getPropertyLength(schema, "binDataMaxLength")
+	return
+}
+
+func (el *TypeBsonBinData) getPropertySubType(schema map[string]interface{}) (subTypeList []byte, err error) {
+	var found bool
+	var value interface{}
+	var list []interface{}
+
+	value, found = schema["binDataSubType"]
+	if found == false {
+		return
+	}
+
+	list, found = value.([]interface{})
+	if found == false {
+		list = []interface{}{value}
+	}
+
+	subTypeList = make([]byte, 0, len(list))
+	for _, subType := range list {
+		var number int64
+		number, err = el.getPropertyAsInt64(map[string]interface{}{"binDataSubType": subType}, "binDataSubType")
+		if err != nil {
+			return
+		}
+
+		if number < 0 || number > 255 {
+			err = errors.New("binDataSubType: the binary subtype must be between 0 and 255")
+			return
+		}
+
+		subTypeList = append(subTypeList, byte(number))
+	}
+
+	return
+}
+
+func (el *TypeBsonBinData) getPropertyLength(schema map[string]interface{}, key string) (set bool, length int64, err error) {
+	var found bool
+
+	_, found = schema[key]
+	if found == false {
+		return
+	}
+
+	length, err = el.getPropertyAsInt64(schema, key)
+	if err != nil {
+		return
+	}
+
+	if length < 0 {
+		err = errors.New(key + ": the length must be a non-negative integer")
+		return
+	}
+
+	set = true
+	return
+}
@@ -0,0 +1,94 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTypeBsonBinData_Verify(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "uuid": {
+        "bsonType": "binData",
+        "binDataSubType": 4,
+        "binDataMinLength": 16,
+        "binDataMaxLength": 16
+      },
+      "firmware": {
+        "bsonType": "binData",
+        "binDataSubType": [0, 128],
+        "binDataMaxLength": 8
+      },
+      "any": { "bsonType": "binData" }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var uuid = primitive.Binary{Subtype: 4, Data: make([]byte, 16)}
+
+	var validList = []map[string]interface{}{
+		{"uuid": uuid, "firmware": []byte{1, 2, 3}, "any": primitive.Binary{Subtype: 5, Data: []byte{}}},
+		{"firmware": primitive.Binary{Subtype: 128, Data: make([]byte, 8)}},
+		{"any": []byte{}},
+	}
+
+	for _, document := range validList {
+		var result = schema.Validate(document)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", document, result.Errors())
+		}
+	}
+
+	var result = schema.Validate(map[string]interface{}{
+		"uuid":     primitive.Binary{Subtype: 3, Data: make([]byte, 15)},
+		"firmware": make([]byte, 9),
+		"any":      "binary",
+	})
+
+	var tests = []struct {
+		path    string
+		keyword string
+	}{
+		{"any", "bsonType"},
+		{"firmware", "binDataMaxLength"},
+		{"uuid", "binDataSubType"},
+	}
+
+	for _, test := range tests {
+		if _, found := validateTestFind(result, test.path, test.keyword); found == false {
+			t.Errorf("violation %v (%v) not found: %v", test.path, test.keyword, result.Errors())
+		}
+	}
+
+	if len(result.Violations) != len(tests) {
+		t.Errorf("expected %v violations, got: %v", len(tests), result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{"uuid": primitive.Binary{Subtype: 4, Data: make([]byte, 15)}})
+	if len(result.Violations) != 1 || result.Violations[0].Keyword != "binDataMinLength" {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+}
+
+func TestTypeBsonBinData_PopulateError(t *testing.T) {
+	var schemaList = []string{
+		`{"properties": {"a": {"bsonType": "binData", "binDataSubType": 256}}}`,
+		`{"properties": {"a": {"bsonType": "binData", "binDataSubType": ["uuid"]}}}`,
+		`{"properties": {"a": {"bsonType": "binData", "binDataMaxLength": -1}}}`,
+	}
+
+	for _, jsonSchema := range schemaList {
+		var schema = MongoDBJsonSchema{}
+		if schema.UnmarshalJSON([]byte(jsonSchema)) == nil {
+			t.Errorf("%v: error expected", jsonSchema)
+		}
+	}
+}
@@ -1,7 +1,5 @@
 package iotmakerdbmongodbutilschema
 
-import "errors"
-
 // The boolean schema type configures the content of fields that are either true or
 // false.
 // For more information, see the official JSON Schema boolean guide.
@@ -20,6 +18,11 @@ func (el *TypeBsonBool) Populate(schema map[string]interface{}) (err error) {
 }
 
 func (el *TypeBsonBool) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("bool", value)
+	if err != nil {
+		return
+	}
+
 	err = el.verifyParent(value)
 	if err != nil {
 		return
@@ -33,7 +36,7 @@ func (el *TypeBsonBool) VerifyType(value interface{}) (err error) {
 	switch value.(type) {
 	case bool:
 	default:
-		err = errors.New("wrong type")
+		err = newViolation("bsonType", "bool", value, "wrong type")
 	}
 
 	return
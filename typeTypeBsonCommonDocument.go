@@ -0,0 +1,375 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// documentPrimitiveStructList (English): Structs that the mongo driver does not save as
+// documents
+//
+// documentPrimitiveStructList (Português): Structs que o driver do mongo não grava como
+// documentos
+var documentPrimitiveStructList = map[reflect.Type]bool{
+	reflect.TypeOf(time.Time{}):               true,
+	reflect.TypeOf(url.URL{}):                 true,
+	reflect.TypeOf(primitive.Binary{}):        true,
+	reflect.TypeOf(primitive.Decimal128{}):    true,
+	reflect.TypeOf(primitive.Regex{}):         true,
+	reflect.TypeOf(primitive.DBPointer{}):     true,
+	reflect.TypeOf(primitive.Timestamp{}):     true,
+	reflect.TypeOf(primitive.CodeWithScope{}): true,
+	reflect.TypeOf(primitive.MinKey{}):        true,
+	reflect.TypeOf(primitive.MaxKey{}):        true,
+	reflect.TypeOf(primitive.Undefined{}):     true,
+	reflect.TypeOf(primitive.Null{}):          true,
+}
+
+// documentValueMarshalerType (English): Structs with this interface choose their own
+// bsonType and must be marshaled to be known
+//
+// documentValueMarshalerType (Português): Structs com esta interface escolhem o seu
+// próprio bsonType e precisam ser convertidas para que ele seja conhecido
+var documentValueMarshalerType = reflect.TypeOf((*bsoncodec.ValueMarshaler)(nil)).Elem()
+
+// documentView (English): Document in any representation produced by the mongo driver,
+// with the keys in the order used to validate and report the fields.
+// map[string]interface{} and primitive.M have no order and the keys are in alphabetical
+// order; primitive.D and bson.Raw keep the order of the document.
+//
+// documentView (Português): Documento em qualquer representação produzida pelo driver
+// do mongo, com as chaves na ordem usada para validar e reportar os campos.
+// map[string]interface{} e primitive.M não têm ordem e as chaves ficam em ordem
+// alfabética; primitive.D e bson.Raw mantêm a ordem do documento.
+type documentView struct {
+	keyList []string
+	values  map[string]interface{}
+}
+
+// parentConvertInterfaceToDocumentView (English): Converts map[string]interface{},
+// primitive.M, primitive.D, bson.Raw, bson.RawValue, maps with string keys and structs
+// into a documentView.
+// Only the first level of bson.Raw is decoded; sub documents are kept as bson.Raw and
+// arrays as bson.RawValue, and are decoded when they are validated. Structs are
+// marshaled into bson.Raw by the mongo driver.
+//
+// parentConvertInterfaceToDocumentView (Português): Converte map[string]interface{},
+// primitive.M, primitive.D, bson.Raw, bson.RawValue, maps com chaves string e structs
+// em um documentView.
+// Apenas o primeiro nível de bson.Raw é decodificado; sub documentos são mantidos como
+// bson.Raw e arrays como bson.RawValue, e são decodificados quando são validados.
+// Structs são convertidas em bson.Raw pelo driver do mongo.
+func (el *TypeBsonCommonToAllTypes) parentConvertInterfaceToDocumentView(value interface{}) (view documentView, err error) {
+	switch document := value.(type) {
+	case map[string]interface{}:
+		view = el.parentNewDocumentViewFromMap(document)
+
+	case primitive.M:
+		view = el.parentNewDocumentViewFromMap(document)
+
+	case primitive.D:
+		view.keyList = make([]string, 0, len(document))
+		view.values = make(map[string]interface{}, len(document))
+		for _, element := range document {
+			if _, found := view.values[element.Key]; found == false {
+				view.keyList = append(view.keyList, element.Key)
+			}
+
+			view.values[element.Key] = element.Value
+		}
+
+	case bson.Raw:
+		err = document.Validate()
+		if err != nil {
+			return
+		}
+
+		var elementList []bson.RawElement
+		elementList, err = document.Elements()
+		if err != nil {
+			return
+		}
+
+		view.keyList = make([]string, 0, len(elementList))
+		view.values = make(map[string]interface{}, len(elementList))
+		for _, element := range elementList {
+			var key = element.Key()
+			if _, found := view.values[key]; found == false {
+				view.keyList = append(view.keyList, key)
+			}
+
+			view.values[key] = el.parentConvertRawValue(element.Value())
+		}
+
+	case bson.RawValue:
+		if document.Type != bsontype.EmbeddedDocument {
+			err = errors.New("wrong type")
+			return
+		}
+
+		view, err = el.parentConvertInterfaceToDocumentView(bson.Raw(document.Value))
+
+	default:
+		var reflectValue = reflect.ValueOf(value)
+		if reflectValue.Kind() == reflect.Map && reflectValue.Type().Key().Kind() == reflect.String {
+			var converted = make(map[string]interface{}, reflectValue.Len())
+			var iterator = reflectValue.MapRange()
+			for iterator.Next() == true {
+				converted[iterator.Key().String()] = iterator.Value().Interface()
+			}
+
+			view = el.parentNewDocumentViewFromMap(converted)
+			return
+		}
+
+		var raw bson.Raw
+		raw, err = el.parentConvertStructToRaw(value)
+		if err != nil {
+			return
+		}
+
+		view, err = el.parentConvertInterfaceToDocumentView(raw)
+	}
+
+	return
+}
+
+// parentVerifyInterfaceTypeIsDocument (English): Verifies if the value is a document
+// without decoding it
+//
+// parentVerifyInterfaceTypeIsDocument (Português): Verifica se o valor é um documento
+// sem decodificá-lo
+func (el *TypeBsonCommonToAllTypes) parentVerifyInterfaceTypeIsDocument(value interface{}) (err error) {
+	switch document := value.(type) {
+	case map[string]interface{}, primitive.M, primitive.D, bson.Raw:
+	case bson.RawValue:
+		if document.Type != bsontype.EmbeddedDocument {
+			err = errors.New("wrong type")
+		}
+	default:
+		var reflectValue = reflect.ValueOf(value)
+		if reflectValue.Kind() == reflect.Map && reflectValue.Type().Key().Kind() == reflect.String {
+			return
+		}
+
+		var structType reflect.Type
+		structType, err = el.parentGetDocumentStructType(value)
+		if err != nil {
+			return
+		}
+
+		if structType.Implements(documentValueMarshalerType) == true || reflect.PtrTo(structType).Implements(documentValueMarshalerType) == true {
+			_, err = el.parentConvertStructToRaw(value)
+		}
+	}
+
+	return
+}
+
+// parentGetDocumentStructType (English): Returns the type of a struct, or of a pointer to
+// a struct, that can be saved as a document, without marshaling it
+//
+// parentGetDocumentStructType (Português): Retorna o tipo de uma struct, ou de um
+// ponteiro para uma struct, que pode ser gravada como documento, sem convertê-la
+func (el *TypeBsonCommonToAllTypes) parentGetDocumentStructType(value interface{}) (structType reflect.Type, err error) {
+	var reflectValue = reflect.ValueOf(value)
+	for reflectValue.Kind() == reflect.Ptr && reflectValue.IsNil() == false {
+		reflectValue = reflectValue.Elem()
+	}
+
+	if reflectValue.Kind() != reflect.Struct || documentPrimitiveStructList[reflectValue.Type()] == true {
+		err = errors.New("wrong type")
+		return
+	}
+
+	structType = reflectValue.Type()
+	return
+}
+
+// parentConvertStructToDocument (English): Marshals a struct saved as a document into
+// bson.Raw, so the rules of all types of the value, composition and enum use the same
+// conversion. Other values are returned as they are.
+//
+// parentConvertStructToDocument (Português): Converte uma struct gravada como documento
+// em bson.Raw, assim as regras de todos os tipos do valor, composição e enum usam a
+// mesma conversão. Os outros valores são retornados como estão.
+func (el *TypeBsonCommonToAllTypes) parentConvertStructToDocument(value interface{}) (converted interface{}) {
+	switch value.(type) {
+	case nil, map[string]interface{}, primitive.M, primitive.D, primitive.A, []interface{}, bson.Raw, bson.RawValue, string:
+		return value
+	}
+
+	if _, err := el.parentGetDocumentStructType(value); err != nil {
+		return value
+	}
+
+	raw, err := el.parentConvertStructToRaw(value)
+	if err != nil {
+		return value
+	}
+
+	return raw
+}
+
+// parentConvertStructToRaw (English): Marshals a struct, or a pointer to a struct, with
+// the mongo driver, so the bson tags, embedded structs and field types are the same
+// that will be saved in the database
+//
+// parentConvertStructToRaw (Português): Converte uma struct, ou um ponteiro para uma
+// struct, com o driver do mongo, assim as tags bson, structs embutidas e tipos dos
+// campos são os mesmos que serão gravados no banco de dados
+func (el *TypeBsonCommonToAllTypes) parentConvertStructToRaw(value interface{}) (raw bson.Raw, err error) {
+	_, err = el.parentGetDocumentStructType(value)
+	if err != nil {
+		return
+	}
+
+	// (English): a struct with MarshalBSONValue() can be saved as a type that is not a
+	// document
+	//
+	// (Português): uma struct com MarshalBSONValue() pode ser gravada como um tipo que não
+	// é um documento
+	var bsonType bsontype.Type
+	var data []byte
+	bsonType, data, err = bson.MarshalValue(value)
+	if err != nil {
+		return
+	}
+
+	if bsonType != bsontype.EmbeddedDocument {
+		err = errors.New("wrong type")
+		return
+	}
+
+	raw = data
+	return
+}
+
+// parentNewDocumentViewFromMap (English): Creates the documentView of a map, with the
+// keys in alphabetical order
+//
+// parentNewDocumentViewFromMap (Português): Cria o documentView de um mapa, com as
+// chaves em ordem alfabética
+func (el *TypeBsonCommonToAllTypes) parentNewDocumentViewFromMap(document map[string]interface{}) (view documentView) {
+	view.values = document
+	view.keyList = make([]string, 0, len(document))
+	for key := range document {
+		view.keyList = append(view.keyList, key)
+	}
+
+	sort.Strings(view.keyList)
+	return
+}
+
+// parentConvertInterfaceToDocument (English): Converts map[string]interface{},
+// primitive.M, primitive.D and bson.Raw into map[string]interface{}
+//
+// parentConvertInterfaceToDocument (Português): Converte map[string]interface{},
+// primitive.M, primitive.D e bson.Raw em map[string]interface{}
+func (el *TypeBsonCommonToAllTypes) parentConvertInterfaceToDocument(value interface{}) (converted map[string]interface{}, isDocument bool) {
+	view, err := el.parentConvertInterfaceToDocumentView(value)
+	if err != nil {
+		return
+	}
+
+	return view.values, true
+}
+
+// parentConvertRawValue (English): Decodes one value of a bson.Raw document into the
+// type used by the mongo driver when it decodes into interface{}. Sub documents are
+// kept as bson.Raw and arrays as bson.RawValue.
+//
+// parentConvertRawValue (Português): Decodifica um valor de um documento bson.Raw no
+// tipo usado pelo driver do mongo quando decodifica em interface{}. Sub documentos são
+// mantidos como bson.Raw e arrays como bson.RawValue.
+func (el *TypeBsonCommonToAllTypes) parentConvertRawValue(value bson.RawValue) (converted interface{}) {
+	switch value.Type {
+	case bsontype.Double:
+		return value.Double()
+	case bsontype.String:
+		return value.StringValue()
+	case bsontype.EmbeddedDocument:
+		return value.Document()
+	case bsontype.Array:
+		return value
+	case bsontype.Binary:
+		subType, data := value.Binary()
+		return primitive.Binary{Subtype: subType, Data: data}
+	case bsontype.Undefined:
+		return primitive.Undefined{}
+	case bsontype.ObjectID:
+		return value.ObjectID()
+	case bsontype.Boolean:
+		return value.Boolean()
+	case bsontype.DateTime:
+		return primitive.DateTime(value.DateTime())
+	case bsontype.Null:
+		return nil
+	case bsontype.Regex:
+		pattern, options := value.Regex()
+		return primitive.Regex{Pattern: pattern, Options: options}
+	case bsontype.DBPointer:
+		db, pointer := value.DBPointer()
+		return primitive.DBPointer{DB: db, Pointer: pointer}
+	case bsontype.JavaScript:
+		return primitive.JavaScript(value.JavaScript())
+	case bsontype.Symbol:
+		return primitive.Symbol(value.Symbol())
+	case bsontype.CodeWithScope:
+		code, scope := value.CodeWithScope()
+		return primitive.CodeWithScope{Code: primitive.JavaScript(code), Scope: scope}
+	case bsontype.Int32:
+		return value.Int32()
+	case bsontype.Timestamp:
+		t, i := value.Timestamp()
+		return primitive.Timestamp{T: t, I: i}
+	case bsontype.Int64:
+		return value.Int64()
+	case bsontype.Decimal128:
+		return value.Decimal128()
+	case bsontype.MinKey:
+		return primitive.MinKey{}
+	case bsontype.MaxKey:
+		return primitive.MaxKey{}
+	}
+
+	return value
+}
+
+// parentConvertRawArray (English): Decodes the first level of an array of a bson.Raw
+// document
+//
+// parentConvertRawArray (Português): Decodifica o primeiro nível de um array de um
+// documento bson.Raw
+func (el *TypeBsonCommonToAllTypes) parentConvertRawArray(value bson.RawValue) (converted []interface{}, err error) {
+	if value.Type != bsontype.Array {
+		err = errors.New("wrong type")
+		return
+	}
+
+	err = value.Validate()
+	if err != nil {
+		return
+	}
+
+	var valueList []bson.RawValue
+	valueList, err = value.Array().Values()
+	if err != nil {
+		return
+	}
+
+	converted = make([]interface{}, len(valueList))
+	for k := range valueList {
+		converted[k] = el.parentConvertRawValue(valueList[k])
+	}
+
+	return
+}
@@ -0,0 +1,466 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func documentTestInvalidD() bson.D {
+	return bson.D{
+		{Key: "_id", Value: "not an object id"},
+		{Key: "name", Value: "dino sauro rex"},
+		{Key: "status", Value: "standby"},
+		{Key: "age", Value: 100},
+		{Key: "active", Value: "yes"},
+		{Key: "street", Value: bson.D{{Key: "name", Value: 10}}},
+		{Key: "friends", Value: bson.A{
+			bson.D{{Key: "name", Value: "Ana"}},
+			bson.D{{Key: "name", Value: "Roberto"}},
+		}},
+	}
+}
+
+func documentTestPathList(result ValidationResult) (pathList []string) {
+	pathList = make([]string, 0, len(result.Violations))
+	for _, violation := range result.Violations {
+		pathList = append(pathList, violation.Path)
+	}
+
+	return
+}
+
+func TestTypeBsonObject_ValidateDriverDocuments(t *testing.T) {
+	var err error
+	var schema = validateTestGetSchema(t)
+
+	var raw bson.Raw
+	raw, err = bson.Marshal(documentTestInvalidD())
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var tests = []struct {
+		name     string
+		document interface{}
+	}{
+		{"bson.D", documentTestInvalidD()},
+		{"bson.M", documentTestInvalidD().Map()},
+		{"primitive.M", primitive.M(documentTestInvalidD().Map())},
+		{"bson.Raw", raw},
+	}
+
+	for _, test := range tests {
+		var result = schema.Validate(test.document)
+
+		for _, expected := range []struct {
+			path    string
+			keyword string
+		}{
+			{"_id", "bsonType"},
+			{"name", "maxLength"},
+			{"status", "enum"},
+			{"age", "maximum"},
+			{"active", "bsonType"},
+			{"street.name", "bsonType"},
+			{"street.number", "required"},
+			{"friends.1.name", "maxLength"},
+		} {
+			if _, found := validateTestFind(result, expected.path, expected.keyword); found == false {
+				t.Errorf("%v: violation %v (%v) not found: %v", test.name, expected.path, expected.keyword, result.Errors())
+			}
+		}
+
+		if len(result.Violations) != 8 {
+			t.Errorf("%v: expected 8 violations, got %v: %v", test.name, len(result.Violations), result.Errors())
+		}
+	}
+}
+
+func TestTypeBsonObject_ValidateDriverDocumentsValid(t *testing.T) {
+	var err error
+	var schema = validateTestGetSchema(t)
+
+	var document = bson.D{
+		{Key: "_id", Value: primitive.NewObjectID()},
+		{Key: "name", Value: "Dino Sauro"},
+		{Key: "age", Value: int32(20)},
+		{Key: "active", Value: true},
+		{Key: "street", Value: bson.M{"name": "Main Street", "number": int64(10)}},
+		{Key: "friends", Value: []bson.D{{{Key: "name", Value: "Ana"}}}},
+	}
+
+	var raw bson.Raw
+	raw, err = bson.Marshal(document)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	for _, value := range []interface{}{document, document.Map(), raw} {
+		var result = schema.Validate(value)
+		if result.Valid() == false {
+			t.Errorf("%T: unexpected violations: %v", value, result.Errors())
+		}
+	}
+}
+
+func TestTypeBsonObject_ValidateFieldOrder(t *testing.T) {
+	var err error
+	var schema = validateTestGetSchema(t)
+
+	var document = bson.D{
+		{Key: "street", Value: bson.D{{Key: "number", Value: 0}, {Key: "name", Value: 10}}},
+		{Key: "name", Value: "dino sauro rex"},
+		{Key: "age", Value: 100},
+	}
+
+	var raw bson.Raw
+	raw, err = bson.Marshal(document)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var expected = []string{"street.number", "street.name", "name", "age"}
+	for _, value := range []interface{}{document, raw} {
+		var pathList = documentTestPathList(schema.Validate(value))
+		if len(pathList) != len(expected) {
+			t.Errorf("%T: expected %v, got %v", value, expected, pathList)
+			continue
+		}
+
+		for k := range expected {
+			if pathList[k] != expected[k] {
+				t.Errorf("%T: expected %v, got %v", value, expected, pathList)
+				break
+			}
+		}
+	}
+
+	// (English): maps have no order and the keys are validated in alphabetical order
+	//
+	// (Português): mapas não têm ordem e as chaves são validadas em ordem alfabética
+	var pathList = documentTestPathList(schema.Validate(document.Map()))
+	if len(pathList) != 4 || pathList[0] != "age" || pathList[1] != "name" {
+		t.Errorf("unexpected order: %v", pathList)
+	}
+}
+
+func TestTypeBsonObject_ValidateTypedMap(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "color": { "bsonType": "string", "maxLength": 5 },
+      "size": { "bsonType": "int", "maximum": 10 },
+      "labels": {
+        "bsonType": "object",
+        "properties": {
+          "color": { "bsonType": "string", "maxLength": 5 },
+          "size": { "bsonType": "int", "maximum": 10 }
+        }
+      }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	type documentTestKey string
+
+	var validList = []interface{}{
+		map[string]string{"color": "red"},
+		map[documentTestKey]int{"size": 5},
+		bson.M{"labels": map[string]string{"color": "red"}},
+		bson.D{{Key: "labels", Value: map[documentTestKey]int32{"size": 5}}},
+	}
+
+	for _, value := range validList {
+		var result = schema.Validate(value)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", value, result.Errors())
+		}
+	}
+
+	var tests = []struct {
+		value    interface{}
+		expected []string
+	}{
+		{map[string]string{"size": "big", "color": "purple"}, []string{"color", "size"}},
+		{map[string]int{"size": 11}, []string{"size"}},
+		{bson.M{"labels": map[string]string{"size": "big", "color": "purple"}}, []string{"labels.color", "labels.size"}},
+		{bson.M{"labels": map[documentTestKey]int{"size": 11}}, []string{"labels.size"}},
+	}
+
+	for _, test := range tests {
+		var pathList = documentTestPathList(schema.Validate(test.value))
+		if reflect.DeepEqual(pathList, test.expected) == false {
+			t.Errorf("%v: expected %v, got %v", test.value, test.expected, pathList)
+		}
+	}
+}
+
+func TestTypeBsonObject_ValidateRawValues(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`{
+		"bsonType": "object",
+		"properties": {
+			"date": { "bsonType": "date" },
+			"decimal": { "bsonType": "decimal", "minimum": "1.5" },
+			"timestamp": { "bsonType": "timestamp" },
+			"binary": { "bsonType": "binData" },
+			"list": {
+				"bsonType": "array",
+				"uniqueItems": true,
+				"items": { "bsonType": ["int", "array"], "items": { "bsonType": "string" } }
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	decimal, _ := primitive.ParseDecimal128("1.25")
+	var raw bson.Raw
+	raw, err = bson.Marshal(bson.D{
+		{Key: "date", Value: primitive.DateTime(1000)},
+		{Key: "decimal", Value: decimal},
+		{Key: "timestamp", Value: primitive.Timestamp{T: 1, I: 2}},
+		{Key: "binary", Value: primitive.Binary{Data: []byte{1}}},
+		{Key: "list", Value: bson.A{1, 1, bson.A{"a", 2}}},
+	})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var result = schema.Validate(raw)
+	if len(result.Violations) != 3 {
+		t.Fatalf("expected 3 violations, got %v", result.Errors())
+	}
+
+	if _, found := validateTestFind(result, "decimal", "minimum"); found == false {
+		t.Errorf("violation not found: %v", result.Errors())
+	}
+
+	if _, found := validateTestFind(result, "list", "uniqueItems"); found == false {
+		t.Errorf("violation not found: %v", result.Errors())
+	}
+
+	if _, found := validateTestFind(result, "list.2.1", "bsonType"); found == false {
+		t.Errorf("violation not found: %v", result.Errors())
+	}
+}
+
+func TestTypeBsonObject_ValidateInvalidRaw(t *testing.T) {
+	var schema = validateTestGetSchema(t)
+
+	var result = schema.Validate(bson.Raw{0x05, 0x00, 0x00})
+	if len(result.Violations) != 1 || result.Violations[0].Keyword != "bsonType" {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+}
+
+type documentTestStreet struct {
+	Name   string `bson:"name,omitempty"`
+	Number int    `bson:"number,omitempty"`
+}
+
+// DocumentTestPerson is exported because the mongo driver does not marshal embedded
+// structs of unexported types
+type DocumentTestPerson struct {
+	Name string `bson:"name"`
+	Age  *int   `bson:"age,omitempty"`
+}
+
+type documentTestStruct struct {
+	ID                 primitive.ObjectID `bson:"_id"`
+	DocumentTestPerson `bson:",inline"`
+	Status             string               `bson:"status,omitempty"`
+	Street             *documentTestStreet  `bson:"street,omitempty"`
+	Friends            []documentTestStreet `bson:"friends"`
+	CreatedAt          time.Time            `bson:"createdAt"`
+	ignored            string
+}
+
+func TestTypeBsonObject_ValidateStruct(t *testing.T) {
+	var err error
+	var schema = validateTestGetSchema(t)
+	var age = 100
+
+	var tests = []struct {
+		name       string
+		document   documentTestStruct
+		violations []string
+	}{
+		{
+			name: "valid",
+			document: documentTestStruct{
+				ID:                 primitive.NewObjectID(),
+				DocumentTestPerson: DocumentTestPerson{Name: "Dino"},
+				Street:             &documentTestStreet{Name: "Main Street", Number: 10},
+				Friends:            []documentTestStreet{{Name: "Ana", Number: 1}},
+				CreatedAt:          time.Now(),
+			},
+		},
+		{
+			name: "invalid",
+			document: documentTestStruct{
+				ID:                 primitive.NewObjectID(),
+				DocumentTestPerson: DocumentTestPerson{Name: "dino sauro rex", Age: &age},
+				Status:             "standby",
+				Street:             &documentTestStreet{},
+				Friends:            []documentTestStreet{{Name: "Roberto"}},
+				ignored:            "not marshaled",
+			},
+			violations: []string{"name", "age", "status", "street.number", "friends.0.name"},
+		},
+		{
+			// (English): street is omitted and the nil slice of friends is saved as null
+			//
+			// (Português): street é omitida e o slice nil de friends é gravado como null
+			name:       "omitempty",
+			document:   documentTestStruct{ID: primitive.NewObjectID(), DocumentTestPerson: DocumentTestPerson{Name: "Dino"}},
+			violations: []string{"street", "friends"},
+		},
+	}
+
+	for _, test := range tests {
+		var raw bson.Raw
+		raw, err = bson.Marshal(test.document)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+
+		var expected = schema.Validate(raw)
+		for _, value := range []interface{}{test.document, &test.document} {
+			var result = schema.Validate(value)
+			if reflect.DeepEqual(result.Errors(), expected.Errors()) == false {
+				t.Errorf("%v: %T: expected %v, got %v", test.name, value, expected.Errors(), result.Errors())
+			}
+
+			var pathList = documentTestPathList(result)
+			if len(pathList) != len(test.violations) {
+				t.Errorf("%v: %T: expected %v, got %v", test.name, value, test.violations, result.Errors())
+				continue
+			}
+
+			for k := range pathList {
+				if pathList[k] != test.violations[k] {
+					t.Errorf("%v: %T: expected %v, got %v", test.name, value, test.violations, result.Errors())
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestTypeBsonObject_ValidateStructField(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`{
+		"bsonType": "object",
+		"properties": {
+			"street": { "bsonType": "object", "required": ["number"] },
+			"createdAt": { "bsonType": "date" },
+			"binary": { "bsonType": "object" }
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var result = schema.Validate(bson.M{
+		"street":    documentTestStreet{Name: "Main Street"},
+		"createdAt": time.Now(),
+		"binary":    primitive.Binary{Data: []byte{1}},
+	})
+	if len(result.Violations) != 2 {
+		t.Fatalf("expected 2 violations, got %v", result.Errors())
+	}
+
+	if _, found := validateTestFind(result, "street.number", "required"); found == false {
+		t.Errorf("violation not found: %v", result.Errors())
+	}
+
+	if _, found := validateTestFind(result, "binary", "bsonType"); found == false {
+		t.Errorf("violation not found: %v", result.Errors())
+	}
+}
+
+type documentTestCounter struct {
+	count *int
+}
+
+func (el documentTestCounter) MarshalBSON() ([]byte, error) {
+	*el.count += 1
+	return bson.Marshal(bson.D{{Key: "name", Value: "Dino"}})
+}
+
+type documentTestValueMarshaler struct{}
+
+func (el documentTestValueMarshaler) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue("text")
+}
+
+func TestTypeBsonObject_ValidateStructMarshaledOnce(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`{
+		"bsonType": "object",
+		"anyOf": [{ "required": ["name"] }],
+		"properties": {
+			"person": {
+				"bsonType": ["object", "string"],
+				"anyOf": [{ "required": ["name"] }, { "required": ["age"] }],
+				"properties": { "name": { "bsonType": "string" } }
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var count int
+	var result = schema.Validate(documentTestCounter{count: &count})
+	if result.Valid() == false || count != 1 {
+		t.Errorf("expected 1 conversion, got %v: %v", count, result.Errors())
+	}
+
+	count = 0
+	result = schema.Validate(bson.M{"name": "Ana", "person": &documentTestCounter{count: &count}})
+	if result.Valid() == false || count != 1 {
+		t.Errorf("expected 1 conversion, got %v: %v", count, result.Errors())
+	}
+}
+
+func TestTypeBsonCommonToAllTypes_VerifyStructIsDocument(t *testing.T) {
+	var common TypeBsonCommonToAllTypes
+	var decimal, _ = primitive.ParseDecimal128("1.5")
+
+	for _, value := range []interface{}{time.Now(), decimal, primitive.Binary{}, &primitive.Timestamp{}, documentTestValueMarshaler{}, (*documentTestStreet)(nil)} {
+		if common.parentVerifyInterfaceTypeIsDocument(value) == nil {
+			t.Errorf("%T must not be a document", value)
+		}
+
+		if reflect.DeepEqual(common.parentConvertStructToDocument(value), value) == false {
+			t.Errorf("%T must not be converted", value)
+		}
+	}
+
+	for _, value := range []interface{}{documentTestStreet{}, &documentTestStreet{}} {
+		if common.parentVerifyInterfaceTypeIsDocument(value) != nil {
+			t.Errorf("%T must be a document", value)
+		}
+
+		if _, isRaw := common.parentConvertStructToDocument(value).(bson.Raw); isRaw == false {
+			t.Errorf("%T must be converted", value)
+		}
+	}
+}
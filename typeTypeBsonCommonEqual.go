@@ -0,0 +1,136 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"math/big"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// parentCompareValues (English): Compares two values using the BSON comparison rules:
+// numbers are equal across int, long, double and decimal; documents and arrays are
+// compared in depth, with the keys of the documents in order; ObjectId and dates are
+// compared by value.
+// Maps have no order and their keys are compared in alphabetical order.
+//
+// parentCompareValues (Português): Compara dois valores usando as regras de comparação
+// do BSON: números são iguais entre int, long, double e decimal; documentos e arrays
+// são comparados em profundidade, com as chaves dos documentos em ordem; ObjectId e
+// datas são comparados pelo valor.
+// Mapas não têm ordem e suas chaves são comparadas em ordem alfabética.
+func (el *TypeBsonCommonToAllTypes) parentCompareValues(a, b interface{}) (equal bool) {
+	if el.parentIsNull(a) == true || el.parentIsNull(b) == true {
+		return el.parentIsNull(a) == true && el.parentIsNull(b) == true
+	}
+
+	numberA, isNumberA := el.parentConvertInterfaceToRat(a)
+	numberB, isNumberB := el.parentConvertInterfaceToRat(b)
+	if isNumberA == true || isNumberB == true {
+		return isNumberA == true && isNumberB == true && numberA.Cmp(numberB) == 0
+	}
+
+	dateA, isDateA := el.parentConvertInterfaceToTime(a)
+	dateB, isDateB := el.parentConvertInterfaceToTime(b)
+	if isDateA == true || isDateB == true {
+		return isDateA == true && isDateB == true && dateA.Equal(dateB)
+	}
+
+	documentA, errA := el.parentConvertInterfaceToDocumentView(a)
+	documentB, errB := el.parentConvertInterfaceToDocumentView(b)
+	if errA == nil || errB == nil {
+		if errA != nil || errB != nil || len(documentA.keyList) != len(documentB.keyList) {
+			return false
+		}
+
+		for k, key := range documentA.keyList {
+			if key != documentB.keyList[k] || el.parentCompareValues(documentA.values[key], documentB.values[key]) == false {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	listA, errA := el.parentConvertInterfaceToSlice(a)
+	listB, errB := el.parentConvertInterfaceToSlice(b)
+	if errA == nil || errB == nil {
+		if errA != nil || errB != nil || len(listA) != len(listB) {
+			return false
+		}
+
+		for k := range listA {
+			if el.parentCompareValues(listA[k], listB[k]) == false {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// parentConvertInterfaceToRat (English): Converts int, long, double and decimal values
+// into an exact rational number
+//
+// parentConvertInterfaceToRat (Português): Converte valores int, long, double e decimal
+// em um número racional exato
+func (el *TypeBsonCommonToAllTypes) parentConvertInterfaceToRat(value interface{}) (converted *big.Rat, isNumber bool) {
+	converted = new(big.Rat)
+
+	switch number := value.(type) {
+	case int:
+		converted.SetInt64(int64(number))
+	case int8:
+		converted.SetInt64(int64(number))
+	case int16:
+		converted.SetInt64(int64(number))
+	case int32:
+		converted.SetInt64(int64(number))
+	case int64:
+		converted.SetInt64(number)
+	case uint:
+		converted.SetUint64(uint64(number))
+	case uint8:
+		converted.SetUint64(uint64(number))
+	case uint16:
+		converted.SetUint64(uint64(number))
+	case uint32:
+		converted.SetUint64(uint64(number))
+	case uint64:
+		converted.SetUint64(number)
+	case float32:
+		if converted.SetFloat64(float64(number)) == nil {
+			return nil, false
+		}
+	case float64:
+		if converted.SetFloat64(number) == nil {
+			return nil, false
+		}
+	case primitive.Decimal128:
+		if _, ok := converted.SetString(number.String()); ok == false {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	return converted, true
+}
+
+// parentConvertInterfaceToTime (English): Converts time.Time and primitive.DateTime into
+// time.Time with millisecond precision
+//
+// parentConvertInterfaceToTime (Português): Converte time.Time e primitive.DateTime em
+// time.Time com precisão de milissegundos
+func (el *TypeBsonCommonToAllTypes) parentConvertInterfaceToTime(value interface{}) (converted time.Time, isTime bool) {
+	switch date := value.(type) {
+	case time.Time:
+		return date.Truncate(time.Millisecond), true
+	case primitive.DateTime:
+		return time.Unix(0, int64(date)*int64(time.Millisecond)), true
+	}
+
+	return
+}
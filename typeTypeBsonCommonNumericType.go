@@ -0,0 +1,72 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"math"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// parentGetStoredNumericType (English): Returns the bsonType used by the mongo driver, with
+// the default encoder, to store the Go value: int, long, double or decimal. Returns an
+// empty string if the value is not a number.
+//
+//   int8, int16, int32, uint8, uint16:   int
+//   int:                                 int, or long when it does not fit in int32
+//   int64, uint, uint32, uint64:         long
+//   float32, float64:                    double
+//   primitive.Decimal128:                decimal
+//
+// parentGetStoredNumericType (Português): Retorna o bsonType usado pelo driver do mongo,
+// com o codificador padrão, para guardar o valor do Go: int, long, double ou decimal.
+// Retorna uma string vazia se o valor não for um número.
+func (el *TypeBsonCommonToAllTypes) parentGetStoredNumericType(value interface{}) (bsonType string) {
+	if _, isDecimal := value.(primitive.Decimal128); isDecimal == true {
+		return "decimal"
+	}
+
+	if value == nil {
+		return
+	}
+
+	var reflectValue = reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		bsonType = "int"
+	case reflect.Int:
+		bsonType = "long"
+		if reflectValue.Int() >= math.MinInt32 && reflectValue.Int() <= math.MaxInt32 {
+			bsonType = "int"
+		}
+	case reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		bsonType = "long"
+	case reflect.Float32, reflect.Float64:
+		bsonType = "double"
+	}
+
+	return
+}
+
+// verifyStrictNumericType (English): With ParseOptions.StrictNumericTypes, verifies that
+// the mongo driver stores the value with the expected bsonType
+//
+// verifyStrictNumericType (Português): Com ParseOptions.StrictNumericTypes, verifica se o
+// driver do mongo guarda o valor com o bsonType esperado
+func (el *TypeBsonCommonToAllTypes) verifyStrictNumericType(bsonType string, value interface{}) (err error) {
+	if el.options.StrictNumericTypes == false || value == nil {
+		return
+	}
+
+	var stored = el.parentGetStoredNumericType(value)
+	if stored == bsonType {
+		return
+	}
+
+	if stored == "" {
+		err = newViolation("bsonType", bsonType, value, "wrong type")
+		return
+	}
+
+	err = newViolation("bsonType", bsonType, value, "wrong type. the value is stored as "+stored)
+	return
+}
@@ -0,0 +1,79 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"math"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTypeBsonCommon_StrictNumericTypes(t *testing.T) {
+	var jsonSchema = []byte(`
+  {
+    "properties": {
+      "int": { "bsonType": "int" },
+      "long": { "bsonType": "long" },
+      "double": { "bsonType": "double" },
+      "decimal": { "bsonType": "decimal" },
+      "number": { "bsonType": ["int", "long"] },
+      "generic": { "minimum": 0 }
+    }
+  }
+  `)
+
+	var decimal, _ = primitive.ParseDecimal128("1.5")
+
+	var tests = []struct {
+		key    string
+		value  interface{}
+		strict bool
+	}{
+		{"int", int32(1), true},
+		{"int", int16(1), true},
+		{"int", 1, true},
+		{"int", math.MaxInt32 + 1, false},
+		{"int", int64(1), false},
+		{"int", 1.0, false},
+		{"long", int64(math.MaxInt32 + 1), true},
+		{"long", uint32(1), true},
+		{"long", int32(1), false},
+		{"long", 1, false},
+		{"double", 1.5, true},
+		{"double", float32(1.5), true},
+		{"double", int32(1), false},
+		{"decimal", decimal, true},
+		{"decimal", 1.5, false},
+		{"number", int64(1), true},
+		{"number", 1.0, false},
+		{"generic", 1, true},
+	}
+
+	var schema = MongoDBJsonSchema{}
+	var err = schema.UnmarshalJSON(jsonSchema)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var strict = MongoDBJsonSchema{}
+	err = strict.UnmarshalJSONWithOptions(jsonSchema, ParseOptions{StrictNumericTypes: true})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	for _, test := range tests {
+		var document = map[string]interface{}{test.key: test.value}
+		var result = strict.Validate(document)
+		if result.Valid() != test.strict {
+			t.Errorf("%v: %T(%v): unexpected result: %v", test.key, test.value, test.value, result.Errors())
+		}
+
+		if test.strict == false && len(result.Filter("bsonType")) != 1 {
+			t.Errorf("%v: %T(%v): expected a bsonType violation: %v", test.key, test.value, test.value, result.Errors())
+		}
+	}
+
+	var result = schema.Validate(map[string]interface{}{"int": int64(1), "double": int32(1)})
+	if result.Valid() == false {
+		t.Errorf("the default mode must convert numeric types: %v", result.Errors())
+	}
+}
@@ -0,0 +1,120 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"sort"
+)
+
+// validateRule (English): Validates the value against a single rule and collects the
+// violations in result
+//
+// validateRule (Português): Valida o valor contra uma única regra e coleta as violações
+// em result
+func (el *TypeBsonCommonToAllTypes) validateRule(path string, rule InterfaceBson, value interface{}, result *ValidationResult) {
+	if rule == nil {
+		return
+	}
+
+	if walker, ok := rule.(interfaceBsonValidate); ok == true {
+		walker.validate(path, value, result)
+		return
+	}
+
+	result.appendError(path, value, rule.Verify(value))
+}
+
+// validateProperty (English): Validates the value against the list of types allowed for
+// one key. The value is valid when any one of the types accepts it.
+//
+// validateProperty (Português): Valida o valor contra a lista de tipos permitidos para
+// uma chave. O valor é válido quando qualquer um dos tipos o aceita.
+func (el *TypeBsonCommonToAllTypes) validateProperty(path string, alternatives map[string]BsonType, value interface{}, result *ValidationResult) {
+	value = el.parentConvertStructToDocument(value)
+
+	var typeList = make([]string, 0, len(alternatives))
+	for typeString, rule := range alternatives {
+		// (English): this occurs for types not implemented
+		//
+		// (Português): isto ocorre para tipos não implementados
+		if rule.ElementType == nil {
+			continue
+		}
+
+		typeList = append(typeList, typeString)
+	}
+
+	if len(typeList) == 0 {
+		return
+	}
+
+	sort.Strings(typeList)
+
+	if len(typeList) == 1 {
+		el.validateRule(path, alternatives[typeList[0]].ElementType, value, result)
+		return
+	}
+
+	var typeMatched bool
+	var violationList = make([]Violation, 0)
+	for _, typeString := range typeList {
+		var partial ValidationResult
+		el.validateRule(path, alternatives[typeString].ElementType, value, &partial)
+		if partial.Valid() == true {
+			return
+		}
+
+		if len(partial.Violations) == 1 && partial.Violations[0].Keyword == "bsonType" && partial.Violations[0].Path == path {
+			continue
+		}
+
+		typeMatched = true
+		for _, violation := range partial.Violations {
+			if el.containsViolation(violationList, violation) == false {
+				violationList = append(violationList, violation)
+			}
+		}
+	}
+
+	if typeMatched == true {
+		result.append(violationList...)
+		return
+	}
+
+	result.append(Violation{
+		Path:     path,
+		Keyword:  "bsonType",
+		Expected: typeList,
+		Actual:   value,
+		Message:  "wrong type",
+	})
+}
+
+// containsViolation (English): Numeric types can share the same rule, as in the JSON
+// type 'number', and the same failure must be reported once
+//
+// containsViolation (Português): Tipos numéricos podem compartilhar a mesma regra, como
+// no tipo JSON 'number', e a mesma falha deve ser reportada uma vez
+func (el *TypeBsonCommonToAllTypes) containsViolation(violationList []Violation, violation Violation) bool {
+	for _, v := range violationList {
+		if v.Path == violation.Path && v.Keyword == violation.Keyword && v.Message == violation.Message {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateProperties (English): Validates each key of the document that has rules in
+// properties, in the order of the document keys
+//
+// validateProperties (Português): Valida cada chave do documento que tem regras em
+// properties, na ordem das chaves do documento
+func (el *TypeBsonCommonToAllTypes) validateProperties(path string, properties map[string]map[string]BsonType, document documentView, result *ValidationResult) {
+	for _, key := range document.keyList {
+		alternatives, found := properties[key]
+		if found == false {
+			continue
+		}
+
+		el.validateProperty(joinPath(path, key), alternatives, document.values[key], result)
+	}
+}
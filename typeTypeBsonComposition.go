@@ -0,0 +1,236 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TypeBsonComposition (English): Common part of the composition keywords allOf, anyOf,
+// oneOf and not. Each entry of Schemas is one schema document of the keyword, indexed by
+// bsonType.
+//
+//   Example:
+//   {
+//     "allOf": [ <Schema Document>, ... ],
+//     "anyOf": [ <Schema Document>, ... ],
+//     "oneOf": [ <Schema Document>, ... ],
+//     "not": <Schema Document>
+//   }
+//
+// TypeBsonComposition (Português): Parte comum das palavras chave de composição allOf,
+// anyOf, oneOf e not. Cada entrada de Schemas é um documento de esquema da palavra
+// chave, indexado pelo bsonType.
+type TypeBsonComposition struct {
+	TypeBsonCommonToAllTypes
+
+	Schemas []map[string]BsonType
+}
+
+func (el *TypeBsonComposition) VerifyErros() (errorList []error) {
+	return nil
+}
+
+// populateSchemas (English): Populates Schemas from the array of schema documents
+// contained in the keyword
+//
+// populateSchemas (Português): Popula Schemas a partir do array de documentos de esquema
+// contido na palavra chave
+func (el *TypeBsonComposition) populateSchemas(keyword string, schema map[string]interface{}) (err error) {
+	var list []interface{}
+	var found bool
+
+	list, found = schema[keyword].([]interface{})
+	if found == false {
+		err = errors.New("'" + keyword + "' key must be a array of schema documents")
+		return
+	}
+
+	if len(list) == 0 {
+		err = errors.New("'" + keyword + "' key must have at least one schema document")
+		return
+	}
+
+	el.Schemas = make([]map[string]BsonType, 0, len(list))
+	for _, item := range list {
+		err = el.populateSchema(keyword, item)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (el *TypeBsonComposition) populateSchema(keyword string, item interface{}) (err error) {
+	var element TypeBsonObject
+	var alternatives map[string]BsonType
+
+	element.setParseOptions(el.options)
+
+	itemSchema, found := item.(map[string]interface{})
+	if found == false {
+		err = errors.New("'" + keyword + "' key must contain schema documents")
+		return
+	}
+
+	alternatives, err = element.populateAlternatives(itemSchema)
+	if err != nil {
+		err = errors.New(keyword + ": " + err.Error())
+		return
+	}
+
+	el.Schemas = append(el.Schemas, alternatives)
+	return
+}
+
+// validateSchemas (English): Validates the value against each schema document and
+// returns the violations of the failed ones and the position of the accepted ones
+//
+// validateSchemas (Português): Valida o valor contra cada documento de esquema e retorna
+// as violações dos que falharam e a posição dos que aceitaram
+func (el *TypeBsonComposition) validateSchemas(value interface{}) (failList []BranchViolation, passList []int) {
+	failList = make([]BranchViolation, 0)
+	passList = make([]int, 0)
+
+	for branch, alternatives := range el.Schemas {
+		var partial ValidationResult
+		el.validateProperty("", alternatives, value, &partial)
+		if partial.Valid() == true {
+			passList = append(passList, branch)
+			continue
+		}
+
+		failList = append(failList, BranchViolation{Branch: branch, Violations: partial.Violations})
+	}
+
+	return
+}
+
+// The value must be valid against all schema documents of the array.
+type TypeBsonAllOf struct {
+	TypeBsonComposition
+}
+
+func (el *TypeBsonAllOf) getTypeString() string {
+	return "allOf"
+}
+
+func (el *TypeBsonAllOf) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateSchemas("allOf", schema)
+	return
+}
+
+func (el *TypeBsonAllOf) Verify(value interface{}) (err error) {
+	failList, _ := el.validateSchemas(value)
+	if len(failList) == 0 {
+		return
+	}
+
+	err = Violation{
+		Keyword:  "allOf",
+		Expected: len(el.Schemas),
+		Actual:   value,
+		Message:  "the value does not match all schemas of allOf",
+		Branches: failList,
+	}
+	return
+}
+
+// The value must be valid against at least one schema document of the array.
+type TypeBsonAnyOf struct {
+	TypeBsonComposition
+}
+
+func (el *TypeBsonAnyOf) getTypeString() string {
+	return "anyOf"
+}
+
+func (el *TypeBsonAnyOf) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateSchemas("anyOf", schema)
+	return
+}
+
+func (el *TypeBsonAnyOf) Verify(value interface{}) (err error) {
+	failList, passList := el.validateSchemas(value)
+	if len(passList) != 0 {
+		return
+	}
+
+	err = Violation{
+		Keyword:  "anyOf",
+		Expected: len(el.Schemas),
+		Actual:   value,
+		Message:  "the value does not match any schema of anyOf",
+		Branches: failList,
+	}
+	return
+}
+
+// The value must be valid against exactly one schema document of the array.
+type TypeBsonOneOf struct {
+	TypeBsonComposition
+}
+
+func (el *TypeBsonOneOf) getTypeString() string {
+	return "oneOf"
+}
+
+func (el *TypeBsonOneOf) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateSchemas("oneOf", schema)
+	return
+}
+
+func (el *TypeBsonOneOf) Verify(value interface{}) (err error) {
+	failList, passList := el.validateSchemas(value)
+	if len(passList) == 1 {
+		return
+	}
+
+	if len(passList) == 0 {
+		err = Violation{
+			Keyword:  "oneOf",
+			Expected: 1,
+			Actual:   value,
+			Message:  "the value does not match any schema of oneOf",
+			Branches: failList,
+		}
+		return
+	}
+
+	err = Violation{
+		Keyword:  "oneOf",
+		Expected: 1,
+		Actual:   value,
+		Message:  fmt.Sprintf("the value matches more than one schema of oneOf: %v", passList),
+	}
+	return
+}
+
+// The value must not be valid against the schema document.
+type TypeBsonNot struct {
+	TypeBsonComposition
+}
+
+func (el *TypeBsonNot) getTypeString() string {
+	return "not"
+}
+
+func (el *TypeBsonNot) Populate(schema map[string]interface{}) (err error) {
+	el.Schemas = make([]map[string]BsonType, 0, 1)
+	err = el.populateSchema("not", schema["not"])
+	return
+}
+
+func (el *TypeBsonNot) Verify(value interface{}) (err error) {
+	_, passList := el.validateSchemas(value)
+	if len(passList) == 0 {
+		return
+	}
+
+	err = Violation{
+		Keyword: "not",
+		Actual:  value,
+		Message: "the value must not match the schema of not",
+	}
+	return
+}
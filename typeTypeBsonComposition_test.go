@@ -0,0 +1,184 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"strings"
+	"testing"
+)
+
+const compositionTestSchema = `
+{
+  "$jsonSchema": {
+    "bsonType": "object",
+    "required": ["kind"],
+    "properties": {
+      "kind": {
+        "enum": ["click", "scroll"]
+      },
+      "code": {
+        "oneOf": [
+          { "bsonType": "int", "multipleOf": 2 },
+          { "bsonType": "int", "multipleOf": 3 }
+        ]
+      },
+      "label": {
+        "bsonType": "string",
+        "allOf": [
+          { "minLength": 2 },
+          { "pattern": "^[a-z]+$" }
+        ],
+        "not": { "enum": ["admin"] }
+      },
+      "payload": {
+        "bsonType": "object",
+        "properties": {
+          "value": {
+            "anyOf": [
+              { "bsonType": "string" },
+              { "bsonType": "int", "minimum": 0 }
+            ]
+          }
+        }
+      }
+    },
+    "anyOf": [
+      {
+        "required": ["x", "y"],
+        "properties": {
+          "kind": { "enum": ["click"] },
+          "x": { "bsonType": "int" },
+          "y": { "bsonType": "int" }
+        }
+      },
+      {
+        "required": ["delta"],
+        "properties": {
+          "kind": { "enum": ["scroll"] },
+          "delta": { "bsonType": "int" }
+        }
+      }
+    ]
+  }
+}
+`
+
+func compositionTestGetSchema(t *testing.T) (schema MongoDBJsonSchema) {
+	var err error
+	err = schema.UnmarshalJSON([]byte(compositionTestSchema))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	return
+}
+
+func TestTypeBsonComposition_Valid(t *testing.T) {
+	var schema = compositionTestGetSchema(t)
+
+	var documentList = []map[string]interface{}{
+		{"kind": "click", "x": 1, "y": 2},
+		{"kind": "scroll", "delta": -3},
+		{"kind": "scroll", "delta": 1, "code": 4, "label": "abc"},
+		{"kind": "click", "x": 1, "y": 2, "code": 9, "payload": map[string]interface{}{"value": "ok"}},
+		{"kind": "click", "x": 1, "y": 2, "payload": map[string]interface{}{"value": 3}},
+	}
+
+	for _, document := range documentList {
+		var result = schema.Validate(document)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", document, result.Errors())
+		}
+	}
+}
+
+func TestTypeBsonComposition_AnyOf(t *testing.T) {
+	var schema = compositionTestGetSchema(t)
+
+	var result = schema.Validate(map[string]interface{}{"kind": "click", "delta": 3})
+	if len(result.Violations) != 1 {
+		t.Fatalf("expected 1 violation, got: %v", result.Errors())
+	}
+
+	var violation = result.Violations[0]
+	if violation.Keyword != "anyOf" || violation.Path != "" || len(violation.Branches) != 2 {
+		t.Fatalf("unexpected violation: %#v", violation)
+	}
+
+	if violation.Branches[0].Branch != 0 || violation.Branches[0].Violations[0].Path != "x" {
+		t.Errorf("unexpected branch: %#v", violation.Branches[0])
+	}
+
+	if violation.Branches[1].Branch != 1 || violation.Branches[1].Violations[0].Path != "kind" {
+		t.Errorf("unexpected branch: %#v", violation.Branches[1])
+	}
+
+	if strings.Contains(violation.Error(), "branch 1: kind:") == false {
+		t.Errorf("unexpected message: %v", violation.Error())
+	}
+}
+
+func TestTypeBsonComposition_Nested(t *testing.T) {
+	var schema = compositionTestGetSchema(t)
+
+	var result = schema.Validate(map[string]interface{}{
+		"kind":    "scroll",
+		"delta":   1,
+		"payload": map[string]interface{}{"value": -1},
+	})
+	if len(result.Violations) != 1 {
+		t.Fatalf("expected 1 violation, got: %v", result.Errors())
+	}
+
+	var violation = result.Violations[0]
+	if violation.Keyword != "anyOf" || violation.Path != "payload.value" {
+		t.Fatalf("unexpected violation: %#v", violation)
+	}
+
+	if violation.Branches[0].Violations[0].Keyword != "bsonType" || violation.Branches[1].Violations[0].Keyword != "minimum" {
+		t.Errorf("unexpected branches: %v", violation.Error())
+	}
+
+	if violation.Branches[1].Violations[0].Path != "payload.value" {
+		t.Errorf("unexpected branch path: %v", violation.Branches[1].Violations[0].Path)
+	}
+}
+
+func TestTypeBsonComposition_OneOf(t *testing.T) {
+	var schema = compositionTestGetSchema(t)
+
+	var result = schema.Validate(map[string]interface{}{"kind": "scroll", "delta": 1, "code": 6})
+	if len(result.Filter("oneOf")) != 1 || result.Violations[0].Path != "code" {
+		t.Errorf("expected oneOf violation, got: %v", result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{"kind": "scroll", "delta": 1, "code": 5})
+	if len(result.Filter("oneOf")) != 1 || len(result.Violations[0].Branches) != 2 {
+		t.Errorf("expected oneOf violation, got: %v", result.Errors())
+	}
+}
+
+func TestTypeBsonComposition_AllOfNot(t *testing.T) {
+	var schema = compositionTestGetSchema(t)
+
+	var result = schema.Validate(map[string]interface{}{"kind": "scroll", "delta": 1, "label": "A"})
+	if len(result.Filter("allOf")) != 1 || len(result.Violations[0].Branches) != 2 {
+		t.Errorf("expected allOf violation, got: %v", result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{"kind": "scroll", "delta": 1, "label": "admin"})
+	if len(result.Filter("not")) != 1 || result.Violations[0].Path != "label" {
+		t.Errorf("expected not violation, got: %v", result.Errors())
+	}
+}
+
+func TestTypeBsonComposition_PopulateError(t *testing.T) {
+	var schema = MongoDBJsonSchema{}
+	var err = schema.UnmarshalJSON([]byte(`{"properties": {"a": {"anyOf": {"bsonType": "int"}}}}`))
+	if err == nil {
+		t.Fail()
+	}
+
+	err = schema.UnmarshalJSON([]byte(`{"properties": {"a": {"oneOf": []}}}`))
+	if err == nil {
+		t.Fail()
+	}
+}
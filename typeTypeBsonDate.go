@@ -3,38 +3,40 @@ package iotmakerdbmongodbutilschema
 import (
 	"errors"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// The numeric schema type configures the content of numeric fields, such as integers and
-// decimals.
-// For more information, see the official JSON Schema numeric guide.
-// https://json-schema.org/understanding-json-schema/reference/numeric.html
+// The date schema type accepts time.Time, primitive.DateTime and Extended JSON
+// {"$date": ...} values. Dates are compared with millisecond precision, as stored by
+// MongoDB.
+//
+// The bounds are written as RFC 3339 strings, such as "2020-01-01T00:00:00Z", or as
+// Extended JSON dates, such as {"$date": "2020-01-01T00:00:00Z"} or
+// {"$date": {"$numberLong": "1577836800000"}}.
 //
 //   Example:
 //   {
 //     "bsonType": "date",
-//     "maximum": <number>,
+//     "maximum": <date>,
 //     "exclusiveMaximum": <boolean>,
-//     "minimum": <number>,
+//     "minimum": <date>,
 //     "exclusiveMinimum": <boolean>
 //   }
 type TypeBsonDate struct {
 	TypeBsonCommonToAllTypes
 
-	// An integer divisor of the field value. For example, if multipleOf is set to 3, 6 is
-	// a valid value but 7 is not.
-	MultipleOf int
-
-	// The maximum value of the number.
-	Maximum int
+	// The maximum value of the date.
+	Maximum       primitive.DateTime
+	MaximumHasSet bool
 
 	// Default: false
 	// If true, the field value must be strictly less than the maximum value.
 	// If false, the field value may also be equal to the maximum value.
 	ExclusiveMaximum bool
 
-	// The minimum value of the number.
-	Minimum       int
+	// The minimum value of the date.
+	Minimum       primitive.DateTime
 	MinimumHasSet bool
 
 	// Default: false
@@ -47,12 +49,18 @@ type TypeBsonDate struct {
 }
 
 func (el *TypeBsonDate) Verify(value interface{}) (err error) {
-	if value != nil {
-		value, err = el.TypeBsonCommonToAllTypes.parentConvertInterfaceToInt(value)
-		if err != nil {
-			return
-		}
+	err = el.verifyNotNull("date", value)
+	if err != nil {
+		return
+	}
+
+	var converted interface{}
+	converted, err = el.convertInterfaceToDateTime(value, false)
+	if err != nil {
+		err = newViolation("bsonType", "date", value, err.Error())
+		return
 	}
+	value = converted
 
 	err = el.verifyParent(value)
 	if err != nil {
@@ -74,75 +82,66 @@ func (el *TypeBsonDate) Verify(value interface{}) (err error) {
 }
 
 func (el *TypeBsonDate) VerifyType(value interface{}) (err error) {
-	if value == nil && el.Enum.values == nil {
+	err = el.verifyNotNull("date", value)
+	if err != nil {
 		return
 	}
 
-	if el.Enum.values != nil {
-		err = el.Enum.Verify(value)
-		return
+	_, err = el.convertInterfaceToDateTime(value, false)
+	if err != nil {
+		err = newViolation("bsonType", "date", value, err.Error())
 	}
-	err = el.parentVerifyInterfaceTypeIsInt(value)
 	return
 }
 
 func (el *TypeBsonDate) VerifyMaximum(value interface{}) (err error) {
-	if value == nil {
+	if value == nil || el.MaximumHasSet == false {
 		return
 	}
 
-	if el.Maximum == 0 {
-		return
-	}
-
-	var converted int
-	converted, err = el.parentConvertInterfaceToInt(value)
+	var converted primitive.DateTime
+	converted, err = el.convertInterfaceToDateTime(value, false)
 	if err != nil {
 		return
 	}
 
 	if el.ExclusiveMaximum == true && converted >= el.Maximum {
-		err = errors.New("maximum value exceeded")
+		err = newViolation("maximum", el.Maximum.Time().UTC(), converted.Time().UTC(), "maximum value exceeded")
 		return
 	}
 
 	if el.ExclusiveMaximum == false && converted > el.Maximum {
-		err = errors.New("maximum value exceeded")
-		return
+		err = newViolation("maximum", el.Maximum.Time().UTC(), converted.Time().UTC(), "maximum value exceeded")
 	}
 
 	return
 }
 
 func (el *TypeBsonDate) VerifyMinimum(value interface{}) (err error) {
-	if value == nil {
-		return
-	}
-
-	if el.MinimumHasSet == false {
+	if value == nil || el.MinimumHasSet == false {
 		return
 	}
 
-	var converted int
-	converted, err = el.parentConvertInterfaceToInt(value)
+	var converted primitive.DateTime
+	converted, err = el.convertInterfaceToDateTime(value, false)
 	if err != nil {
 		return
 	}
 
 	if el.ExclusiveMinimum == true && el.Minimum >= converted {
-		err = errors.New("expected minimum value")
+		err = newViolation("minimum", el.Minimum.Time().UTC(), converted.Time().UTC(), "expected minimum value")
 		return
 	}
 
 	if el.ExclusiveMinimum == false && el.Minimum > converted {
-		err = errors.New("expected minimum value")
+		err = newViolation("minimum", el.Minimum.Time().UTC(), converted.Time().UTC(), "expected minimum value")
 	}
 
 	return
 }
 
 func (el *TypeBsonDate) getTypeString() string {
-	return "int"
+	return "date"
 }
 
 func (el *TypeBsonDate) Populate(schema map[string]interface{}) (err error) {
@@ -151,22 +150,15 @@ func (el *TypeBsonDate) Populate(schema map[string]interface{}) (err error) {
 		return
 	}
 
-	err = el.Enum.turnValuesIntoInt()
-	if err != nil {
-		return
-	}
-
-	var multipleOf int64
-	var maximum int64
-	var minimum int64
-	var minimumHasSet bool
-
-	multipleOf, err = el.getPropertyMultipleOf(schema)
-	if err != nil {
-		return
+	for k, v := range el.Enum.values {
+		el.Enum.values[k], err = el.convertInterfaceToDateTime(v, true)
+		if err != nil {
+			err = errors.New("enum: " + err.Error())
+			return
+		}
 	}
 
-	maximum, err = el.getPropertyMaximum(schema)
+	el.MaximumHasSet, el.Maximum, err = el.getPropertyMaximum(schema)
 	if err != nil {
 		return
 	}
@@ -176,58 +168,30 @@ func (el *TypeBsonDate) Populate(schema map[string]interface{}) (err error) {
 		return
 	}
 
-	minimumHasSet, minimum, err = el.getPropertyMinimum(schema)
+	el.MinimumHasSet, el.Minimum, err = el.getPropertyMinimum(schema)
 	if err != nil {
 		return
 	}
 
 	el.ExclusiveMinimum, err = el.getPropertyExclusiveMinimum(schema)
-	if err != nil {
-		return
-	}
-
-	el.MultipleOf = int(multipleOf)
-	el.Maximum = int(maximum)
-	el.Minimum = int(minimum)
-	el.MinimumHasSet = minimumHasSet
-
 	return
 }
 
-func (el *TypeBsonDate) getPropertyMultipleOf(schema map[string]interface{}) (multipleOf int64, err error) {
+func (el *TypeBsonDate) getPropertyMaximum(schema map[string]interface{}) (set bool, maximum primitive.DateTime, err error) {
 	var found bool
 
-	_, found = schema["multipleOf"]
-	if found == false {
-		return
-	}
-
-	multipleOf, err = el.getPropertyAsInt64(schema, "multipleOf")
-	return
-}
-
-func (el *TypeBsonDate) getPropertyMaximum(schema map[string]interface{}) (maximum int64, err error) {
-	var found bool
-	var dateTime time.Time
-	var date string
-
 	_, found = schema["maximum"]
 	if found == false {
 		return
 	}
 
-	date, err = el.getPropertyString(schema, "maximum")
+	maximum, err = el.convertInterfaceToDateTime(schema["maximum"], true)
 	if err != nil {
+		err = errors.New("maximum: " + err.Error())
 		return
 	}
 
-	dateTime, err = time.Parse(dateLayout, date)
-	if err != nil {
-		return
-	}
-
-	maximum = dateTime.Unix()
-
+	set = true
 	return
 }
 
@@ -243,29 +207,21 @@ func (el *TypeBsonDate) getPropertyExclusiveMaximum(schema map[string]interface{
 	return
 }
 
-func (el *TypeBsonDate) getPropertyMinimum(schema map[string]interface{}) (set bool, minimum int64, err error) {
+func (el *TypeBsonDate) getPropertyMinimum(schema map[string]interface{}) (set bool, minimum primitive.DateTime, err error) {
 	var found bool
-	var dateTime time.Time
-	var date string
 
 	_, found = schema["minimum"]
 	if found == false {
 		return
 	}
 
-	set = true
-	date, err = el.getPropertyString(schema, "minimum")
-	if err != nil {
-		return
-	}
-
-	dateTime, err = time.Parse(dateLayout, date)
+	minimum, err = el.convertInterfaceToDateTime(schema["minimum"], true)
 	if err != nil {
+		err = errors.New("minimum: " + err.Error())
 		return
 	}
 
-	minimum = dateTime.Unix()
-
+	set = true
 	return
 }
 
@@ -280,3 +236,93 @@ func (el *TypeBsonDate) getPropertyExclusiveMinimum(schema map[string]interface{
 	exclusiveMinimum, err = el.getPropertyAsBool(schema, "exclusiveMinimum")
 	return
 }
+
+// convertInterfaceToDateTime (English): Converts time.Time, primitive.DateTime and the
+// Extended JSON forms {"$date": "<RFC 3339>"}, {"$date": <milliseconds>} and
+// {"$date": {"$numberLong": "<milliseconds>"}} into primitive.DateTime.
+// When acceptString is true, as in the schema bounds, RFC 3339 strings and strings in
+// the layout defined by DefineNewDateLayout() are also accepted.
+//
+// convertInterfaceToDateTime (Português): Converte time.Time, primitive.DateTime e as
+// formas do Extended JSON {"$date": "<RFC 3339>"}, {"$date": <milissegundos>} e
+// {"$date": {"$numberLong": "<milissegundos>"}} em primitive.DateTime.
+// Quando acceptString é true, como nos limites do esquema, strings RFC 3339 e strings
+// no layout definido por DefineNewDateLayout() também são aceitas.
+func (el *TypeBsonDate) convertInterfaceToDateTime(value interface{}, acceptString bool) (converted primitive.DateTime, err error) {
+	switch date := value.(type) {
+	case primitive.DateTime:
+		converted = date
+	case time.Time:
+		converted = primitive.NewDateTimeFromTime(date)
+	case string:
+		if acceptString == false {
+			err = errors.New("wrong type")
+			return
+		}
+
+		converted, err = el.parseDateString(date)
+	case map[string]interface{}:
+		var found bool
+		var milliseconds int64
+
+		if _, found = date["$date"]; found == false || len(date) != 1 {
+			err = errors.New("wrong type")
+			return
+		}
+
+		switch ejson := date["$date"].(type) {
+		case string:
+			var parsed time.Time
+			parsed, err = time.Parse(time.RFC3339Nano, ejson)
+			if err != nil {
+				return
+			}
+
+			converted = primitive.NewDateTimeFromTime(parsed)
+		case map[string]interface{}:
+			if _, found = ejson["$numberLong"]; found == false {
+				err = errors.New("the key '$date' must have the key '$numberLong'")
+				return
+			}
+
+			milliseconds, err = el.getPropertyAsInt64(ejson, "$numberLong")
+			converted = primitive.DateTime(milliseconds)
+		default:
+			milliseconds, err = el.getPropertyAsInt64(date, "$date")
+			converted = primitive.DateTime(milliseconds)
+		}
+	default:
+		err = errors.New("wrong type")
+	}
+
+	return
+}
+
+// parseDateString (English): Parses a RFC 3339 date string or, as fallback, a date string
+// in one of the layouts of ParseOptions.DateLayouts. The layout defined by
+// DefineNewDateLayout() is copied into the options when the schema is populated.
+//
+// parseDateString (Português): Faz o parser de uma string de data RFC 3339 ou, como
+// alternativa, de uma string de data em um dos layouts de ParseOptions.DateLayouts. O
+// layout definido por DefineNewDateLayout() é copiado para as opções quando o esquema é
+// populado.
+func (el *TypeBsonDate) parseDateString(date string) (converted primitive.DateTime, err error) {
+	var dateTime time.Time
+	var layoutList = el.options.DateLayouts
+
+	dateTime, err = time.Parse(time.RFC3339Nano, date)
+	for k := 0; err != nil && k != len(layoutList); k += 1 {
+		var layoutErr error
+		dateTime, layoutErr = time.Parse(layoutList[k], date)
+		if layoutErr == nil {
+			err = nil
+		}
+	}
+
+	if err != nil {
+		return
+	}
+
+	converted = primitive.NewDateTimeFromTime(dateTime)
+	return
+}
@@ -0,0 +1,96 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTypeBsonDate_Verify(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "createdAt": {
+        "bsonType": "date",
+        "minimum": "2020-01-01T00:00:00Z",
+        "maximum": { "$date": { "$numberLong": "1609459200000" } },
+        "exclusiveMaximum": true
+      },
+      "releasedAt": {
+        "bsonType": "date",
+        "enum": [{ "$date": "2020-06-01T12:00:00.123Z" }]
+      }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var minimum = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var released = time.Date(2020, 6, 1, 9, 0, 0, 123456789, time.FixedZone("BRT", -3*60*60))
+
+	var validList = []map[string]interface{}{
+		{"createdAt": minimum},
+		{"createdAt": primitive.NewDateTimeFromTime(minimum.Add(time.Hour))},
+		{"createdAt": map[string]interface{}{"$date": "2020-12-31T23:59:59.999Z"}},
+		{"createdAt": map[string]interface{}{"$date": 1590000000000.0}},
+		{"releasedAt": released},
+	}
+
+	for _, document := range validList {
+		var result = schema.Validate(document)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", document, result.Errors())
+		}
+	}
+
+	var tests = []struct {
+		document map[string]interface{}
+		keyword  string
+	}{
+		{map[string]interface{}{"createdAt": minimum.Add(-time.Millisecond)}, "minimum"},
+		{map[string]interface{}{"createdAt": time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}, "maximum"},
+		{map[string]interface{}{"createdAt": "2020-06-01T00:00:00Z"}, "bsonType"},
+		{map[string]interface{}{"createdAt": int64(1590000000000)}, "bsonType"},
+		{map[string]interface{}{"releasedAt": released.Add(time.Millisecond)}, "enum"},
+	}
+
+	for _, test := range tests {
+		var result = schema.Validate(test.document)
+		if len(result.Violations) != 1 || result.Violations[0].Keyword != test.keyword {
+			t.Errorf("%v: expected %v violation, got: %v", test.document, test.keyword, result.Errors())
+		}
+	}
+
+	// sub millisecond differences are not stored by MongoDB
+	var result = schema.Validate(map[string]interface{}{"createdAt": minimum.Add(999 * time.Microsecond)})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{"createdAt": time.Date(2020, 12, 31, 23, 59, 59, 999999999, time.UTC)})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+}
+
+func TestTypeBsonDate_PopulateError(t *testing.T) {
+	var schemaList = []string{
+		`{"properties": {"a": {"bsonType": "date", "minimum": "yesterday"}}}`,
+		`{"properties": {"a": {"bsonType": "date", "maximum": {"$date": "2020-13-01T00:00:00Z"}}}}`,
+		`{"properties": {"a": {"bsonType": "date", "maximum": {"$date": {"ms": 1}}}}}`,
+		`{"properties": {"a": {"bsonType": "date", "enum": [true]}}}`,
+	}
+
+	for _, jsonSchema := range schemaList {
+		var schema = MongoDBJsonSchema{}
+		if schema.UnmarshalJSON([]byte(jsonSchema)) == nil {
+			t.Errorf("%v: error expected", jsonSchema)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The dbPointer schema type accepts the deprecated BSON DBPointer values, represented by
+// primitive.DBPointer.
+//
+//   Example:
+//   {
+//     "bsonType": "dbPointer"
+//   }
+type TypeBsonDbPointer struct {
+	TypeBsonCommonToAllTypes
+}
+
+func (el *TypeBsonDbPointer) getTypeString() string {
+	return "dbPointer"
+}
+
+func (el *TypeBsonDbPointer) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	return
+}
+
+func (el *TypeBsonDbPointer) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("dbPointer", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	return
+}
+
+func (el *TypeBsonDbPointer) VerifyType(value interface{}) (err error) {
+	switch value.(type) {
+	case primitive.DBPointer:
+	default:
+		err = newViolation("bsonType", "dbPointer", value, "wrong type")
+	}
+
+	return
+}
@@ -2,17 +2,26 @@ package iotmakerdbmongodbutilschema
 
 import (
 	"errors"
-	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// The numeric schema type configures the content of numeric fields, such as integers and
-// decimals.
-// For more information, see the official JSON Schema numeric guide.
-// https://json-schema.org/understanding-json-schema/reference/numeric.html
+// decimalTextRegexp: decimal number in the text form, such as "10", "-0.01" or "1.5E+3"
+var decimalTextRegexp = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// The decimal schema type is validated with exact decimal arithmetic, so a money field
+// with "multipleOf": 0.01 does not suffer from the binary floating point rounding.
+// The value can be a primitive.Decimal128, an Extended JSON {"$numberDecimal": "1.10"},
+// a string decimal, such as "1.10", or a Go number.
+// The bounds can be written as numbers, string decimals or Extended JSON decimals.
 //
 //   Example:
 //   {
-//     "bsonType": "int" | "long" | "double" | "decimal",
+//     "bsonType": "decimal",
 //     "multipleOf": <number>,
 //     "maximum": <number>,
 //     "exclusiveMaximum": <boolean>,
@@ -22,12 +31,16 @@ import (
 type TypeBsonDecimal struct {
 	TypeBsonCommonToAllTypes
 
-	// An integer divisor of the field value. For example, if multipleOf is set to 3, 6 is
-	// a valid value but 7 is not.
-	MultipleOf float32
+	// A divisor of the field value. For example, if multipleOf is set to 0.01, 1.25 is a
+	// valid value but 1.255 is not.
+	// nil means no restriction.
+	MultipleOf       *big.Rat
+	MultipleOfHasSet bool
 
 	// The maximum value of the number.
-	Maximum float32
+	// nil means no restriction.
+	Maximum       *big.Rat
+	MaximumHasSet bool
 
 	// Default: false
 	// If true, the field value must be strictly less than the maximum value.
@@ -35,29 +48,39 @@ type TypeBsonDecimal struct {
 	ExclusiveMaximum bool
 
 	// The minimum value of the number.
-	Minimum       float32
+	Minimum       *big.Rat
 	MinimumHasSet bool
 
 	// Default: false
 	// If true, the field value must be strictly greater than the minimum value.
 	// If false, the field value may also be equal to the minimum value.
 	ExclusiveMinimum bool
+
+	// original text of the bounds, used by the violations
+	multipleOfText string
+	maximumText    string
+	minimumText    string
+
+	// enum values converted to exact decimal numbers
+	enumList []*big.Rat
+
+	// string decimals are accepted as value only when the schema names the 'decimal'
+	// bsonType, so the JSON type 'number' and the alias 'number' do not accept strings
+	acceptString bool
 }
 
 func (el *TypeBsonDecimal) Verify(value interface{}) (err error) {
-	if value != nil {
-		value, err = el.TypeBsonCommonToAllTypes.parentConvertInterfaceToFloat32(value)
-		if err != nil {
-			return
-		}
+	err = el.verifyNotNull("decimal", value)
+	if err != nil {
+		return
 	}
 
-	err = el.verifyParent(value)
+	err = el.VerifyType(value)
 	if err != nil {
 		return
 	}
 
-	err = el.VerifyType(value)
+	err = el.verifyComposition(value)
 	if err != nil {
 		return
 	}
@@ -77,80 +100,91 @@ func (el *TypeBsonDecimal) Verify(value interface{}) (err error) {
 }
 
 func (el *TypeBsonDecimal) VerifyType(value interface{}) (err error) {
-	if value == nil && el.Enum.values == nil {
+	err = el.verifyNotNull("decimal", value)
+	if err != nil {
 		return
 	}
 
-	if el.Enum.values != nil {
-		err = el.Enum.Verify(value)
+	err = el.verifyStrictNumericType("decimal", value)
+	if err != nil {
 		return
 	}
-	err = el.parentVerifyInterfaceTypeIsFloat32(value)
-	return
-}
 
-func (el *TypeBsonDecimal) VerifyMultipleOf(value interface{}) (err error) {
-	var module float64
-	if el.MultipleOf == 0 {
+	if _, isString := value.(string); isString == true && el.acceptString == false {
+		err = newViolation("bsonType", "decimal", value, "wrong type")
 		return
 	}
 
-	var converted float32
-	converted, err = el.parentConvertInterfaceToFloat32(value)
+	var number *big.Rat
+	number, _, err = el.convertInterfaceToDecimal(value)
 	if err != nil {
+		err = newViolation("bsonType", "decimal", value, err.Error())
 		return
 	}
 
-	module = el.round(float64(converted)/float64(el.MultipleOf), 1.0)
-	if module != float64(int64(module)) {
-		err = errors.New(fmt.Sprintf("number must be multiple of %1.2f", el.MultipleOf))
+	if el.enumList == nil {
+		return
+	}
+
+	for _, enum := range el.enumList {
+		if enum.Cmp(number) == 0 {
+			return
+		}
 	}
 
+	err = newViolation("enum", el.Enum.values, value, "the value does not match any value contained in the array")
 	return
 }
 
-func (el *TypeBsonDecimal) VerifyMaximum(value interface{}) (err error) {
-	if el.Maximum == 0 {
+func (el *TypeBsonDecimal) VerifyMultipleOf(value interface{}) (err error) {
+	if value == nil || el.MultipleOfHasSet == false {
 		return
 	}
 
-	var converted float32
-	converted, err = el.parentConvertInterfaceToFloat32(value)
+	number, text, err := el.convertInterfaceToDecimal(value)
 	if err != nil {
 		return
 	}
 
-	if el.ExclusiveMaximum == true && converted >= el.Maximum {
-		err = errors.New("maximum value exceeded")
+	var quotient = new(big.Rat).Quo(number, el.MultipleOf)
+	if quotient.IsInt() == false {
+		err = newViolation("multipleOf", el.multipleOfText, text, "number must be multiple of "+el.multipleOfText)
+	}
+
+	return
+}
+
+func (el *TypeBsonDecimal) VerifyMaximum(value interface{}) (err error) {
+	if value == nil || el.MaximumHasSet == false {
 		return
 	}
 
-	if el.ExclusiveMaximum == false && converted > el.Maximum {
-		err = errors.New("maximum value exceeded")
+	number, text, err := el.convertInterfaceToDecimal(value)
+	if err != nil {
 		return
 	}
 
+	var compare = number.Cmp(el.Maximum)
+	if compare > 0 || el.ExclusiveMaximum == true && compare == 0 {
+		err = newViolation("maximum", el.maximumText, text, "maximum value exceeded")
+	}
+
 	return
 }
 
 func (el *TypeBsonDecimal) VerifyMinimum(value interface{}) (err error) {
-	if el.MinimumHasSet == false {
+	if value == nil || el.MinimumHasSet == false {
 		return
 	}
 
-	var converted float32
-	converted, err = el.parentConvertInterfaceToFloat32(value)
+	number, text, err := el.convertInterfaceToDecimal(value)
 	if err != nil {
 		return
 	}
 
-	if el.ExclusiveMinimum == true && el.Minimum >= converted {
-		err = errors.New("expected minimum value")
-		return
-	}
-
-	if el.ExclusiveMinimum == false && el.Minimum > converted {
-		err = errors.New("expected minimum value")
+	var compare = number.Cmp(el.Minimum)
+	if compare < 0 || el.ExclusiveMinimum == true && compare == 0 {
+		err = newViolation("minimum", el.minimumText, text, "expected minimum value")
 	}
 
 	return
@@ -166,70 +200,87 @@ func (el *TypeBsonDecimal) Populate(schema map[string]interface{}) (err error) {
 		return
 	}
 
-	err = el.Enum.turnValuesIntoFloat32()
-	if err != nil {
-		return
-	}
+	el.acceptString = el.isDecimalNamed(schema)
 
-	var multipleOf float32
-	var maximum float32
-	var minimum float32
-	var minimumHasSet bool
+	el.enumList = nil
+	for _, enum := range el.Enum.values {
+		var number *big.Rat
+		number, _, err = el.convertInterfaceToDecimal(enum)
+		if err != nil {
+			err = errors.New("enum: " + err.Error())
+			return
+		}
 
-	multipleOf, err = el.getPropertyMultipleOf(schema)
+		el.enumList = append(el.enumList, number)
+	}
+
+	el.MultipleOf, el.multipleOfText, err = el.getPropertyDecimal(schema, "multipleOf")
 	if err != nil {
 		return
 	}
 
-	maximum, err = el.getPropertyMaximum(schema)
-	if err != nil {
+	el.MultipleOfHasSet = el.MultipleOf != nil
+	if el.MultipleOfHasSet == true && el.MultipleOf.Sign() <= 0 {
+		err = errors.New("multipleOf: the value must be greater than 0")
 		return
 	}
 
-	el.ExclusiveMaximum, err = el.getPropertyExclusiveMaximum(schema)
+	el.Maximum, el.maximumText, err = el.getPropertyDecimal(schema, "maximum")
 	if err != nil {
 		return
 	}
 
-	minimumHasSet, minimum, err = el.getPropertyMinimum(schema)
+	el.MaximumHasSet = el.Maximum != nil
+
+	el.ExclusiveMaximum, err = el.getPropertyExclusiveMaximum(schema)
 	if err != nil {
 		return
 	}
 
-	el.ExclusiveMinimum, err = el.getPropertyExclusiveMinimum(schema)
+	el.Minimum, el.minimumText, err = el.getPropertyDecimal(schema, "minimum")
 	if err != nil {
 		return
 	}
 
-	el.MultipleOf = multipleOf
-	el.Maximum = maximum
-	el.Minimum = minimum
-	el.MinimumHasSet = minimumHasSet
+	el.MinimumHasSet = el.Minimum != nil
 
+	el.ExclusiveMinimum, err = el.getPropertyExclusiveMinimum(schema)
 	return
 }
 
-func (el *TypeBsonDecimal) getPropertyMultipleOf(schema map[string]interface{}) (multipleOf float32, err error) {
-	var found bool
-
-	_, found = schema["multipleOf"]
-	if found == false {
-		return
+// isDecimalNamed (English): Returns true if the 'bsonType' key of the schema names the
+// 'decimal' type
+//
+// isDecimalNamed (Português): Retorna true se a chave 'bsonType' do esquema nomeia o tipo
+// 'decimal'
+func (el *TypeBsonDecimal) isDecimalNamed(schema map[string]interface{}) bool {
+	switch bsonType := schema["bsonType"].(type) {
+	case string:
+		return bsonType == "decimal"
+	case []interface{}:
+		for _, v := range bsonType {
+			if v == "decimal" {
+				return true
+			}
+		}
 	}
 
-	multipleOf, err = el.getPropertyAsFloat32(schema, "multipleOf")
-	return
+	return false
 }
 
-func (el *TypeBsonDecimal) getPropertyMaximum(schema map[string]interface{}) (maximum float32, err error) {
+func (el *TypeBsonDecimal) getPropertyDecimal(schema map[string]interface{}, key string) (number *big.Rat, text string, err error) {
 	var found bool
 
-	_, found = schema["maximum"]
+	_, found = schema[key]
 	if found == false {
 		return
 	}
 
-	maximum, err = el.getPropertyAsFloat32(schema, "maximum")
+	number, text, err = el.convertInterfaceToDecimal(schema[key])
+	if err != nil {
+		err = errors.New(key + ": " + err.Error())
+	}
+
 	return
 }
 
@@ -245,27 +296,102 @@ func (el *TypeBsonDecimal) getPropertyExclusiveMaximum(schema map[string]interfa
 	return
 }
 
-func (el *TypeBsonDecimal) getPropertyMinimum(schema map[string]interface{}) (set bool, minimum float32, err error) {
+func (el *TypeBsonDecimal) getPropertyExclusiveMinimum(schema map[string]interface{}) (exclusiveMinimum bool, err error) {
 	var found bool
 
-	_, found = schema["minimum"]
+	_, found = schema["exclusiveMinimum"]
 	if found == false {
 		return
 	}
 
-	set = true
-	minimum, err = el.getPropertyAsFloat32(schema, "minimum")
+	exclusiveMinimum, err = el.getPropertyAsBool(schema, "exclusiveMinimum")
 	return
 }
 
-func (el *TypeBsonDecimal) getPropertyExclusiveMinimum(schema map[string]interface{}) (exclusiveMinimum bool, err error) {
-	var found bool
+// convertInterfaceToDecimal (English): Converts primitive.Decimal128, Extended JSON
+// {"$numberDecimal": "1.10"}, string decimals and Go numbers into an exact decimal
+// number. text is the original decimal text of the value.
+// Double values are converted from the shortest text that represents them, so 0.01 is
+// exactly one hundredth.
+//
+// convertInterfaceToDecimal (Português): Converte primitive.Decimal128, Extended JSON
+// {"$numberDecimal": "1.10"}, strings decimais e números do Go em um número decimal
+// exato. text é o texto decimal original do valor.
+// Valores double são convertidos a partir do menor texto que os representa, assim, 0.01
+// é exatamente um centésimo.
+func (el *TypeBsonDecimal) convertInterfaceToDecimal(value interface{}) (number *big.Rat, text string, err error) {
+	switch converted := value.(type) {
+	case primitive.Decimal128:
+		text = converted.String()
+	case map[string]interface{}:
+		var found bool
+		text, found = converted["$numberDecimal"].(string)
+		if found == false || len(converted) != 1 {
+			err = errors.New("wrong type")
+			return
+		}
+	case string:
+		text = converted
+	case int:
+		text = strconv.FormatInt(int64(converted), 10)
+	case int8:
+		text = strconv.FormatInt(int64(converted), 10)
+	case int16:
+		text = strconv.FormatInt(int64(converted), 10)
+	case int32:
+		text = strconv.FormatInt(int64(converted), 10)
+	case int64:
+		text = strconv.FormatInt(converted, 10)
+	case uint:
+		text = strconv.FormatUint(uint64(converted), 10)
+	case uint8:
+		text = strconv.FormatUint(uint64(converted), 10)
+	case uint16:
+		text = strconv.FormatUint(uint64(converted), 10)
+	case uint32:
+		text = strconv.FormatUint(uint64(converted), 10)
+	case uint64:
+		text = strconv.FormatUint(converted, 10)
+	case float32:
+		text = strconv.FormatFloat(float64(converted), 'g', -1, 32)
+	case float64:
+		text = strconv.FormatFloat(converted, 'g', -1, 64)
+	default:
+		err = errors.New("wrong type")
+		return
+	}
 
-	_, found = schema["exclusiveMinimum"]
-	if found == false {
+	if decimalTextRegexp.MatchString(text) == false {
+		err = errors.New("'" + text + "' is not a finite decimal number")
 		return
 	}
 
-	exclusiveMinimum, err = el.getPropertyAsBool(schema, "exclusiveMinimum")
+	number, _ = new(big.Rat).SetString(text)
 	return
 }
+
+// ratToFloat64Bound (English): Converts a number read from a decimal to float64, moving
+// it towards direction (-1 for minimum, 1 for maximum) until the shortest text of the
+// float64 does not cut the number off. Returns false when the number does not fit a
+// float64
+//
+// ratToFloat64Bound (Português): Converte um número lido de um decimal para float64,
+// movendo-o na direção indicada (-1 para minimum, 1 para maximum) até que o menor texto
+// do float64 não corte o número. Retorna false quando o número não cabe em um float64
+func ratToFloat64Bound(number *big.Rat, direction int) (converted float64, ok bool) {
+	converted, _ = number.Float64()
+	for {
+		if math.IsInf(converted, 0) == true {
+			return
+		}
+
+		var rounded, _ = new(big.Rat).SetString(strconv.FormatFloat(converted, 'g', -1, 64))
+		var compare = rounded.Cmp(number)
+		if compare == 0 || compare == direction {
+			ok = true
+			return
+		}
+
+		converted = math.Nextafter(converted, math.Inf(direction))
+	}
+}
@@ -0,0 +1,102 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTypeBsonDecimal_Verify(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "price": {
+        "bsonType": "decimal",
+        "multipleOf": 0.01,
+        "minimum": "0.01",
+        "maximum": { "$numberDecimal": "99999999999999999.99" }
+      },
+      "rate": {
+        "bsonType": "decimal",
+        "enum": ["1.10", 2]
+      },
+      "amount": { "type": "number" }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var decimal = func(text string) primitive.Decimal128 {
+		number, err := primitive.ParseDecimal128(text)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		return number
+	}
+
+	var validList = []map[string]interface{}{
+		{"price": decimal("19.99")},
+		{"price": decimal("0.30")},
+		{"price": 0.3},
+		{"price": "1.10"},
+		{"price": map[string]interface{}{"$numberDecimal": "99999999999999999.99"}},
+		{"price": decimal("1.5E+2")},
+		{"rate": decimal("1.1")},
+		{"rate": 2.0},
+		{"amount": decimal("1.005")},
+	}
+
+	for _, document := range validList {
+		var result = schema.Validate(document)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", document, result.Errors())
+		}
+	}
+
+	var tests = []struct {
+		document map[string]interface{}
+		keyword  string
+		expected string
+		actual   string
+	}{
+		{map[string]interface{}{"price": decimal("10.005")}, "multipleOf", "0.01", "10.005"},
+		{map[string]interface{}{"price": decimal("0.00")}, "minimum", "0.01", "0.00"},
+		{map[string]interface{}{"price": "100000000000000000.00"}, "maximum", "99999999999999999.99", "100000000000000000.00"},
+		{map[string]interface{}{"rate": decimal("1.11")}, "enum", "", ""},
+		{map[string]interface{}{"price": "ten"}, "bsonType", "", ""},
+		{map[string]interface{}{"price": decimal("NaN")}, "bsonType", "", ""},
+		{map[string]interface{}{"amount": "1.005"}, "bsonType", "", ""},
+	}
+
+	for _, test := range tests {
+		var result = schema.Validate(test.document)
+		if len(result.Violations) != 1 || result.Violations[0].Keyword != test.keyword {
+			t.Errorf("%v: expected %v violation, got: %v", test.document, test.keyword, result.Errors())
+			continue
+		}
+
+		if test.expected != "" && (result.Violations[0].Expected != test.expected || result.Violations[0].Actual != test.actual) {
+			t.Errorf("%v: unexpected violation: %#v", test.document, result.Violations[0])
+		}
+	}
+}
+
+func TestTypeBsonDecimal_PopulateError(t *testing.T) {
+	var schemaList = []string{
+		`{"properties": {"a": {"bsonType": "decimal", "maximum": "1/3"}}}`,
+		`{"properties": {"a": {"bsonType": "decimal", "minimum": {"$numberDecimal": 1}}}}`,
+		`{"properties": {"a": {"bsonType": "decimal", "enum": ["one"]}}}`,
+	}
+
+	for _, jsonSchema := range schemaList {
+		var schema = MongoDBJsonSchema{}
+		if schema.UnmarshalJSON([]byte(jsonSchema)) == nil {
+			t.Errorf("%v: error expected", jsonSchema)
+		}
+	}
+}
@@ -24,10 +24,12 @@ type TypeBsonDouble struct {
 
 	// An integer divisor of the field value. For example, if multipleOf is set to 3, 6 is
 	// a valid value but 7 is not.
-	MultipleOf float64
+	MultipleOf       float64
+	MultipleOfHasSet bool
 
 	// The maximum value of the number.
-	Maximum float64
+	Maximum       float64
+	MaximumHasSet bool
 
 	// Default: false
 	// If true, the field value must be strictly less than the maximum value.
@@ -45,13 +47,24 @@ type TypeBsonDouble struct {
 }
 
 func (el *TypeBsonDouble) Verify(value interface{}) (err error) {
-	if value != nil {
-		value, err = el.TypeBsonCommonToAllTypes.parentConvertInterfaceToFloat64(value)
-		if err != nil {
-			return
-		}
+	err = el.verifyNotNull("double", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyStrictNumericType("double", value)
+	if err != nil {
+		return
 	}
 
+	var converted interface{}
+	converted, err = el.TypeBsonCommonToAllTypes.parentConvertInterfaceToFloat64(value)
+	if err != nil {
+		err = newViolation("bsonType", "double", value, err.Error())
+		return
+	}
+	value = converted
+
 	err = el.verifyParent(value)
 	if err != nil {
 		return
@@ -77,7 +90,8 @@ func (el *TypeBsonDouble) Verify(value interface{}) (err error) {
 }
 
 func (el *TypeBsonDouble) VerifyType(value interface{}) (err error) {
-	if value == nil && el.Enum.values == nil {
+	err = el.verifyNotNull("double", value)
+	if err != nil {
 		return
 	}
 
@@ -86,6 +100,9 @@ func (el *TypeBsonDouble) VerifyType(value interface{}) (err error) {
 		return
 	}
 	err = el.parentVerifyInterfaceTypeIsFloat64(value)
+	if err != nil {
+		err = newViolation("bsonType", "double", value, err.Error())
+	}
 	return
 }
 
@@ -95,7 +112,7 @@ func (el *TypeBsonDouble) VerifyMultipleOf(value interface{}) (err error) {
 	}
 
 	var module float64
-	if el.MultipleOf == 0 {
+	if el.MultipleOfHasSet == false {
 		return
 	}
 
@@ -107,7 +124,7 @@ func (el *TypeBsonDouble) VerifyMultipleOf(value interface{}) (err error) {
 
 	module = el.round(converted/el.MultipleOf, 1.0)
 	if module != float64(int64(module)) {
-		err = errors.New(fmt.Sprintf("number must be multiple of %1.2f", el.MultipleOf))
+		err = newViolation("multipleOf", el.MultipleOf, converted, fmt.Sprintf("number must be multiple of %1.2f", el.MultipleOf))
 	}
 
 	return
@@ -118,7 +135,7 @@ func (el *TypeBsonDouble) VerifyMaximum(value interface{}) (err error) {
 		return
 	}
 
-	if el.Maximum == 0 {
+	if el.MaximumHasSet == false {
 		return
 	}
 
@@ -129,12 +146,12 @@ func (el *TypeBsonDouble) VerifyMaximum(value interface{}) (err error) {
 	}
 
 	if el.ExclusiveMaximum == true && converted >= el.Maximum {
-		err = errors.New("maximum value exceeded")
+		err = newViolation("maximum", el.Maximum, converted, "maximum value exceeded")
 		return
 	}
 
 	if el.ExclusiveMaximum == false && converted > el.Maximum {
-		err = errors.New("maximum value exceeded")
+		err = newViolation("maximum", el.Maximum, converted, "maximum value exceeded")
 		return
 	}
 
@@ -157,12 +174,12 @@ func (el *TypeBsonDouble) VerifyMinimum(value interface{}) (err error) {
 	}
 
 	if el.ExclusiveMinimum == true && el.Minimum >= converted {
-		err = errors.New("expected minimum value")
+		err = newViolation("minimum", el.Minimum, converted, "expected minimum value")
 		return
 	}
 
 	if el.ExclusiveMinimum == false && el.Minimum > converted {
-		err = errors.New("expected minimum value")
+		err = newViolation("minimum", el.Minimum, converted, "expected minimum value")
 	}
 
 	return
@@ -184,16 +201,18 @@ func (el *TypeBsonDouble) Populate(schema map[string]interface{}) (err error) {
 	}
 
 	var multipleOf float64
+	var multipleOfHasSet bool
 	var maximum float64
+	var maximumHasSet bool
 	var minimum float64
 	var minimumHasSet bool
 
-	multipleOf, err = el.getPropertyMultipleOf(schema)
+	multipleOfHasSet, multipleOf, err = el.getPropertyMultipleOf(schema)
 	if err != nil {
 		return
 	}
 
-	maximum, err = el.getPropertyMaximum(schema)
+	maximumHasSet, maximum, err = el.getPropertyMaximum(schema)
 	if err != nil {
 		return
 	}
@@ -213,7 +232,9 @@ func (el *TypeBsonDouble) Populate(schema map[string]interface{}) (err error) {
 		return
 	}
 
+	el.MultipleOfHasSet = multipleOfHasSet
 	el.MultipleOf = multipleOf
+	el.MaximumHasSet = maximumHasSet
 	el.Maximum = maximum
 	el.Minimum = minimum
 	el.MinimumHasSet = minimumHasSet
@@ -221,7 +242,7 @@ func (el *TypeBsonDouble) Populate(schema map[string]interface{}) (err error) {
 	return
 }
 
-func (el *TypeBsonDouble) getPropertyMultipleOf(schema map[string]interface{}) (multipleOf float64, err error) {
+func (el *TypeBsonDouble) getPropertyMultipleOf(schema map[string]interface{}) (set bool, multipleOf float64, err error) {
 	var found bool
 
 	_, found = schema["multipleOf"]
@@ -230,10 +251,20 @@ func (el *TypeBsonDouble) getPropertyMultipleOf(schema map[string]interface{}) (
 	}
 
 	multipleOf, err = el.getPropertyAsFloat64(schema, "multipleOf")
+	if err != nil {
+		return
+	}
+
+	if multipleOf <= 0 {
+		err = errors.New("multipleOf: the value must be greater than 0")
+		return
+	}
+
+	set = true
 	return
 }
 
-func (el *TypeBsonDouble) getPropertyMaximum(schema map[string]interface{}) (maximum float64, err error) {
+func (el *TypeBsonDouble) getPropertyMaximum(schema map[string]interface{}) (set bool, maximum float64, err error) {
 	var found bool
 
 	_, found = schema["maximum"]
@@ -241,6 +272,7 @@ func (el *TypeBsonDouble) getPropertyMaximum(schema map[string]interface{}) (max
 		return
 	}
 
+	set = true
 	maximum, err = el.getPropertyAsFloat64(schema, "maximum")
 	return
 }
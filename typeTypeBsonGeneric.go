@@ -7,29 +7,179 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// interfaceBsonParseOptions (English): Implemented by all types through
+// TypeBsonCommonToAllTypes, receives the parse options before Populate()
+//
+// interfaceBsonParseOptions (Português): Implementada por todos os tipos através de
+// TypeBsonCommonToAllTypes, recebe as opções de parser antes de Populate()
+type interfaceBsonParseOptions interface {
+	setParseOptions(options ParseOptions)
+}
+
 type InterfaceBson interface {
 	Populate(schema map[string]interface{}) (err error)
 	Verify(value interface{}) (err error)
 	VerifyErros() (errorList []error)
 }
 
+// interfaceBsonValidate (English): Implemented by the types that contain other rules,
+// such as object and array, so that every violation of the tree is collected
+//
+// interfaceBsonValidate (Português): Implementado pelos tipos que contêm outras regras,
+// como object e array, para que todas as violações da árvore sejam coletadas
+type interfaceBsonValidate interface {
+	validate(path string, value interface{}, result *ValidationResult)
+}
+
 type AdditionalItems interface{}
 
 type Items interface{}
 
+// TypeBsonGeneric (English): Rules of a schema document without 'bsonType'. Enum and
+// composition keywords are always verified. As in JSON Schema, the keywords of the
+// object, array, string and numeric types are verified only when the value is of that
+// type.
+//
+// TypeBsonGeneric (Português): Regras de um documento de esquema sem 'bsonType'. Enum e
+// as palavras chave de composição são sempre verificadas. Como no JSON Schema, as
+// palavras chave dos tipos object, array, string e numérico são verificadas somente
+// quando o valor é daquele tipo.
 type TypeBsonGeneric struct {
 	TypeBsonCommonToAllTypes
+
+	// Rules used when the value is a document
+	Object *TypeBsonObject
+
+	// Rules used when the value is an array
+	Array *TypeBsonArray
+
+	// Rules used when the value is a string
+	String *TypeBsonString
+
+	// Rules used when the value is a number
+	Number *TypeBsonDouble
 }
 
 func (el *TypeBsonGeneric) Verify(value interface{}) (err error) {
-	err = el.verifyParent(value)
+	var result ValidationResult
+	el.validate("", value, &result)
+	if result.Valid() == false {
+		err = result.Violations[0]
+	}
+
 	return
 }
 
+func (el *TypeBsonGeneric) validate(path string, value interface{}, result *ValidationResult) {
+	value = el.parentConvertStructToDocument(value)
+
+	result.appendError(path, value, el.verifyParent(value))
+
+	if el.parentIsNull(value) == true {
+		return
+	}
+
+	if el.parentVerifyInterfaceTypeIsDocument(value) == nil {
+		if el.Object != nil {
+			el.Object.validate(path, value, result)
+		}
+		return
+	}
+
+	switch value.(type) {
+	case string:
+		if el.String != nil {
+			el.validateRule(path, el.String, value, result)
+		}
+
+	default:
+		if el.Array != nil && el.parentVerifyInterfaceTypeIsArray(value) == nil {
+			el.Array.validate(path, value, result)
+		}
+
+		if el.Number != nil && el.parentVerifyInterfaceTypeIsFloat64(value) == nil {
+			el.validateRule(path, el.Number, value, result)
+		}
+	}
+}
+
 func (el *TypeBsonGeneric) Populate(schema map[string]interface{}) (err error) {
 	err = el.populateGeneric(schema)
+	if err != nil {
+		return
+	}
+
+	var typeSchema map[string]interface{}
+	var found bool
+
+	typeSchema, found = el.filterKeys(schema, "properties", "required", "minProperties", "maxProperties", "patternProperties", "additionalProperties", "dependencies")
+	if found == true {
+		el.Object = &TypeBsonObject{}
+		el.Object.setParseOptions(el.options)
+		err = el.Object.Populate(typeSchema)
+		if err != nil {
+			return
+		}
+	}
+
+	typeSchema, found = el.filterKeys(schema, "items", "additionalItems", "maxItems", "minItems", "uniqueItems")
+	if found == true {
+		el.Array = &TypeBsonArray{}
+		el.Array.setParseOptions(el.options)
+		err = el.Array.Populate(typeSchema)
+		if err != nil {
+			return
+		}
+	}
+
+	typeSchema, found = el.filterKeys(schema, "maxLength", "minLength", "pattern")
+	if found == true {
+		el.String = &TypeBsonString{}
+		el.String.setParseOptions(el.options)
+		err = el.String.Populate(typeSchema)
+		if err != nil {
+			return
+		}
+	}
+
+	typeSchema, found = el.filterKeys(schema, "multipleOf", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum")
+	if found == true {
+		// the generic number accepts all numeric types, so the strict numeric types option
+		// is not applied
+		var options = el.options
+		options.StrictNumericTypes = false
+
+		el.Number = &TypeBsonDouble{}
+		el.Number.setParseOptions(options)
+		err = el.Number.Populate(typeSchema)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// filterKeys (English): Returns a new schema with only the keys of the list. found is
+// false when the schema has none of them.
+//
+// filterKeys (Português): Retorna um novo esquema somente com as chaves da lista. found
+// é false quando o esquema não tem nenhuma delas.
+func (el *TypeBsonGeneric) filterKeys(schema map[string]interface{}, keyList ...string) (filtered map[string]interface{}, found bool) {
+	filtered = make(map[string]interface{})
+	for _, key := range keyList {
+		value, keyFound := schema[key]
+		if keyFound == true {
+			filtered[key] = value
+			found = true
+		}
+	}
+
 	return
 }
 
@@ -80,6 +230,9 @@ type TypeBsonCommonToAllTypes struct {
 	// Accept double converted to integer
 	AcceptDoubleConvertedToInteger bool
 
+	// options used by Populate(), copied into every rule created from the schema
+	options ParseOptions
+
 	// An array that includes all valid values for the data that the schema describes
 	Enum Enum
 
@@ -90,6 +243,18 @@ type TypeBsonCommonToAllTypes struct {
 	// A detailed description of the data that the schema models. This field is used for
 	// metadata purposes only and has no impact on schema validation.
 	Description string
+
+	// The value must be valid against all schema documents of the array.
+	AllOf *TypeBsonAllOf
+
+	// The value must be valid against at least one schema document of the array.
+	AnyOf *TypeBsonAnyOf
+
+	// The value must be valid against exactly one schema document of the array.
+	OneOf *TypeBsonOneOf
+
+	// The value must not be valid against the schema document.
+	Not *TypeBsonNot
 }
 
 func (el *TypeBsonCommonToAllTypes) VerifyErros() (errorList []error) {
@@ -118,8 +283,74 @@ func (el *TypeBsonCommonToAllTypes) verify(value interface{}) (err error) {
 	return
 }
 
+// verifyNotNull (English): As in MongoDB, null is accepted only by the 'null' type, so
+// {"bsonType": "string"} rejects null and {"bsonType": ["string", "null"]} accepts it
+//
+// verifyNotNull (Português): Como no MongoDB, null é aceito somente pelo tipo 'null',
+// logo {"bsonType": "string"} rejeita null e {"bsonType": ["string", "null"]} o aceita
+func (el *TypeBsonCommonToAllTypes) verifyNotNull(typeString string, value interface{}) (err error) {
+	if el.parentIsNull(value) == true {
+		err = newViolation("bsonType", typeString, value, "wrong type")
+	}
+
+	return
+}
+
+// parentIsNull (English): Returns true for nil and for nil slices, maps and pointers,
+// which the mongo driver saves as null
+//
+// parentIsNull (Português): Retorna true para nil e para slices, maps e ponteiros nil,
+// que o driver do mongo grava como null
+func (el *TypeBsonCommonToAllTypes) parentIsNull(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	var reflectValue = reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Ptr:
+		return reflectValue.IsNil()
+	}
+
+	return false
+}
+
 func (el *TypeBsonCommonToAllTypes) verifyParent(value interface{}) (err error) {
 	err = el.verifyEnum(value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyComposition(value)
+	return
+}
+
+func (el *TypeBsonCommonToAllTypes) verifyComposition(value interface{}) (err error) {
+	if el.AllOf != nil {
+		err = el.AllOf.Verify(value)
+		if err != nil {
+			return
+		}
+	}
+
+	if el.AnyOf != nil {
+		err = el.AnyOf.Verify(value)
+		if err != nil {
+			return
+		}
+	}
+
+	if el.OneOf != nil {
+		err = el.OneOf.Verify(value)
+		if err != nil {
+			return
+		}
+	}
+
+	if el.Not != nil {
+		err = el.Not.Verify(value)
+	}
+
 	return
 }
 
@@ -138,6 +369,11 @@ func (el *TypeBsonCommonToAllTypes) Populate(schema map[string]interface{}) (err
 }
 
 func (el *TypeBsonCommonToAllTypes) populateGeneric(schema map[string]interface{}) (err error) {
+	err = el.verifyKeywords(schema)
+	if err != nil {
+		return
+	}
+
 	el.Enum, err = el.getPropertyEnum(schema)
 	if err != nil {
 		return
@@ -153,6 +389,78 @@ func (el *TypeBsonCommonToAllTypes) populateGeneric(schema map[string]interface{
 		return
 	}
 
+	el.AllOf, err = el.getPropertyAllOf(schema)
+	if err != nil {
+		return
+	}
+
+	el.AnyOf, err = el.getPropertyAnyOf(schema)
+	if err != nil {
+		return
+	}
+
+	el.OneOf, err = el.getPropertyOneOf(schema)
+	if err != nil {
+		return
+	}
+
+	el.Not, err = el.getPropertyNot(schema)
+	return
+}
+
+func (el *TypeBsonCommonToAllTypes) getPropertyAllOf(schema map[string]interface{}) (allOf *TypeBsonAllOf, err error) {
+	var found bool
+
+	_, found = schema["allOf"]
+	if found == false {
+		return
+	}
+
+	allOf = &TypeBsonAllOf{}
+	allOf.setParseOptions(el.options)
+	err = allOf.Populate(schema)
+	return
+}
+
+func (el *TypeBsonCommonToAllTypes) getPropertyAnyOf(schema map[string]interface{}) (anyOf *TypeBsonAnyOf, err error) {
+	var found bool
+
+	_, found = schema["anyOf"]
+	if found == false {
+		return
+	}
+
+	anyOf = &TypeBsonAnyOf{}
+	anyOf.setParseOptions(el.options)
+	err = anyOf.Populate(schema)
+	return
+}
+
+func (el *TypeBsonCommonToAllTypes) getPropertyOneOf(schema map[string]interface{}) (oneOf *TypeBsonOneOf, err error) {
+	var found bool
+
+	_, found = schema["oneOf"]
+	if found == false {
+		return
+	}
+
+	oneOf = &TypeBsonOneOf{}
+	oneOf.setParseOptions(el.options)
+	err = oneOf.Populate(schema)
+	return
+}
+
+func (el *TypeBsonCommonToAllTypes) getPropertyNot(schema map[string]interface{}) (not *TypeBsonNot, err error) {
+	var found bool
+
+	_, found = schema["not"]
+	if found == false {
+		return
+	}
+
+	not = &TypeBsonNot{}
+	not.setParseOptions(el.options)
+	err = not.Populate(schema)
 	return
 }
 
@@ -748,16 +1056,46 @@ func (el *TypeBsonCommonToAllTypes) parentConvertInterfaceToFloat64(value interf
 	return
 }
 
-func (el *TypeBsonCommonToAllTypes) parentVerifyInterfaceTypeIsArray(value interface{}) (err error) {
-	switch value.(type) {
-	case []map[string]interface{}:
-	default:
+// parentConvertInterfaceToSlice (English): Converts []interface{}, bson.A and typed
+// slices, such as []string or [][]float64, into []interface{}.
+// []byte is binary data and bson.D is a document, so both are not arrays.
+//
+// parentConvertInterfaceToSlice (Português): Converte []interface{}, bson.A e slices
+// tipados, como []string ou [][]float64, em []interface{}.
+// []byte é um dado binário e bson.D é um documento, por isto, ambos não são arrays.
+func (el *TypeBsonCommonToAllTypes) parentConvertInterfaceToSlice(value interface{}) (converted []interface{}, err error) {
+	switch list := value.(type) {
+	case nil:
 		err = errors.New("wrong type")
+	case []interface{}:
+		converted = list
+	case primitive.A:
+		converted = list
+	case bson.RawValue:
+		converted, err = el.parentConvertRawArray(list)
+	case []byte, primitive.D, bson.Raw:
+		err = errors.New("wrong type")
+	default:
+		var reflectValue = reflect.ValueOf(value)
+		if reflectValue.Kind() != reflect.Slice && reflectValue.Kind() != reflect.Array {
+			err = errors.New("wrong type")
+			return
+		}
+
+		converted = make([]interface{}, reflectValue.Len())
+		for k := range converted {
+			converted[k] = reflectValue.Index(k).Interface()
+		}
 	}
 
 	return
 }
 
+func (el *TypeBsonCommonToAllTypes) parentVerifyInterfaceTypeIsArray(value interface{}) (err error) {
+	_, err = el.parentConvertInterfaceToSlice(value)
+	return
+}
+
 func (el *TypeBsonCommonToAllTypes) parentVerifyInterfaceTypeIsInt(value interface{}) (err error) {
 	_, err = el.parentConvertInterfaceToInt(value)
 	return
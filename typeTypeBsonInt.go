@@ -24,10 +24,12 @@ type TypeBsonInt struct {
 
 	// An integer divisor of the field value. For example, if multipleOf is set to 3, 6 is
 	// a valid value but 7 is not.
-	MultipleOf int
+	MultipleOf       int
+	MultipleOfHasSet bool
 
 	// The maximum value of the number.
-	Maximum int
+	Maximum       int
+	MaximumHasSet bool
 
 	// Default: false
 	// If true, the field value must be strictly less than the maximum value.
@@ -48,13 +50,24 @@ type TypeBsonInt struct {
 }
 
 func (el *TypeBsonInt) Verify(value interface{}) (err error) {
-	if value != nil {
-		value, err = el.TypeBsonCommonToAllTypes.parentConvertInterfaceToInt(value)
-		if err != nil {
-			return
-		}
+	err = el.verifyNotNull("int", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyStrictNumericType("int", value)
+	if err != nil {
+		return
 	}
 
+	var converted interface{}
+	converted, err = el.TypeBsonCommonToAllTypes.parentConvertInterfaceToInt(value)
+	if err != nil {
+		err = newViolation("bsonType", "int", value, err.Error())
+		return
+	}
+	value = converted
+
 	err = el.verifyParent(value)
 	if err != nil {
 		return
@@ -80,7 +93,8 @@ func (el *TypeBsonInt) Verify(value interface{}) (err error) {
 }
 
 func (el *TypeBsonInt) VerifyType(value interface{}) (err error) {
-	if value == nil && el.Enum.values == nil {
+	err = el.verifyNotNull("int", value)
+	if err != nil {
 		return
 	}
 
@@ -89,6 +103,9 @@ func (el *TypeBsonInt) VerifyType(value interface{}) (err error) {
 		return
 	}
 	err = el.parentVerifyInterfaceTypeIsInt(value)
+	if err != nil {
+		err = newViolation("bsonType", "int", value, err.Error())
+	}
 	return
 }
 
@@ -98,7 +115,7 @@ func (el *TypeBsonInt) VerifyMultipleOf(value interface{}) (err error) {
 	}
 
 	var module int
-	if el.MultipleOf == 0 {
+	if el.MultipleOfHasSet == false {
 		return
 	}
 
@@ -110,7 +127,7 @@ func (el *TypeBsonInt) VerifyMultipleOf(value interface{}) (err error) {
 
 	module = converted % el.MultipleOf
 	if module != 0 {
-		err = errors.New("number must be multiple of " + strconv.Itoa(el.MultipleOf))
+		err = newViolation("multipleOf", el.MultipleOf, converted, "number must be multiple of "+strconv.Itoa(el.MultipleOf))
 	}
 
 	return
@@ -121,7 +138,7 @@ func (el *TypeBsonInt) VerifyMaximum(value interface{}) (err error) {
 		return
 	}
 
-	if el.Maximum == 0 {
+	if el.MaximumHasSet == false {
 		return
 	}
 
@@ -132,12 +149,12 @@ func (el *TypeBsonInt) VerifyMaximum(value interface{}) (err error) {
 	}
 
 	if el.ExclusiveMaximum == true && converted >= el.Maximum {
-		err = errors.New("maximum value exceeded")
+		err = newViolation("maximum", el.Maximum, converted, "maximum value exceeded")
 		return
 	}
 
 	if el.ExclusiveMaximum == false && converted > el.Maximum {
-		err = errors.New("maximum value exceeded")
+		err = newViolation("maximum", el.Maximum, converted, "maximum value exceeded")
 		return
 	}
 
@@ -160,12 +177,12 @@ func (el *TypeBsonInt) VerifyMinimum(value interface{}) (err error) {
 	}
 
 	if el.ExclusiveMinimum == true && el.Minimum >= converted {
-		err = errors.New("expected minimum value")
+		err = newViolation("minimum", el.Minimum, converted, "expected minimum value")
 		return
 	}
 
 	if el.ExclusiveMinimum == false && el.Minimum > converted {
-		err = errors.New("expected minimum value")
+		err = newViolation("minimum", el.Minimum, converted, "expected minimum value")
 	}
 
 	return
@@ -187,16 +204,18 @@ func (el *TypeBsonInt) Populate(schema map[string]interface{}) (err error) {
 	}
 
 	var multipleOf int64
+	var multipleOfHasSet bool
 	var maximum int64
+	var maximumHasSet bool
 	var minimum int64
 	var minimumHasSet bool
 
-	multipleOf, err = el.getPropertyMultipleOf(schema)
+	multipleOfHasSet, multipleOf, err = el.getPropertyMultipleOf(schema)
 	if err != nil {
 		return
 	}
 
-	maximum, err = el.getPropertyMaximum(schema)
+	maximumHasSet, maximum, err = el.getPropertyMaximum(schema)
 	if err != nil {
 		return
 	}
@@ -216,7 +235,9 @@ func (el *TypeBsonInt) Populate(schema map[string]interface{}) (err error) {
 		return
 	}
 
+	el.MultipleOfHasSet = multipleOfHasSet
 	el.MultipleOf = int(multipleOf)
+	el.MaximumHasSet = maximumHasSet
 	el.Maximum = int(maximum)
 	el.Minimum = int(minimum)
 	el.MinimumHasSet = minimumHasSet
@@ -224,7 +245,7 @@ func (el *TypeBsonInt) Populate(schema map[string]interface{}) (err error) {
 	return
 }
 
-func (el *TypeBsonInt) getPropertyMultipleOf(schema map[string]interface{}) (multipleOf int64, err error) {
+func (el *TypeBsonInt) getPropertyMultipleOf(schema map[string]interface{}) (set bool, multipleOf int64, err error) {
 	var found bool
 
 	_, found = schema["multipleOf"]
@@ -233,10 +254,20 @@ func (el *TypeBsonInt) getPropertyMultipleOf(schema map[string]interface{}) (mul
 	}
 
 	multipleOf, err = el.getPropertyAsInt64(schema, "multipleOf")
+	if err != nil {
+		return
+	}
+
+	if multipleOf <= 0 {
+		err = errors.New("multipleOf: the value must be greater than 0")
+		return
+	}
+
+	set = true
 	return
 }
 
-func (el *TypeBsonInt) getPropertyMaximum(schema map[string]interface{}) (maximum int64, err error) {
+func (el *TypeBsonInt) getPropertyMaximum(schema map[string]interface{}) (set bool, maximum int64, err error) {
 	var found bool
 
 	_, found = schema["maximum"]
@@ -244,6 +275,7 @@ func (el *TypeBsonInt) getPropertyMaximum(schema map[string]interface{}) (maximu
 		return
 	}
 
+	set = true
 	maximum, err = el.getPropertyAsInt64(schema, "maximum")
 	return
 }
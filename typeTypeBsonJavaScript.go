@@ -0,0 +1,49 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The javascript schema type accepts JavaScript code, represented by primitive.JavaScript.
+//
+//   Example:
+//   {
+//     "bsonType": "javascript"
+//   }
+type TypeBsonJavaScript struct {
+	TypeBsonCommonToAllTypes
+}
+
+func (el *TypeBsonJavaScript) getTypeString() string {
+	return "javascript"
+}
+
+func (el *TypeBsonJavaScript) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	return
+}
+
+func (el *TypeBsonJavaScript) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("javascript", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	return
+}
+
+func (el *TypeBsonJavaScript) VerifyType(value interface{}) (err error) {
+	switch value.(type) {
+	case primitive.JavaScript:
+	default:
+		err = newViolation("bsonType", "javascript", value, "wrong type")
+	}
+
+	return
+}
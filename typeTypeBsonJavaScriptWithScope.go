@@ -0,0 +1,50 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The javascriptWithScope schema type accepts JavaScript code with scope, represented by
+// primitive.CodeWithScope.
+//
+//   Example:
+//   {
+//     "bsonType": "javascriptWithScope"
+//   }
+type TypeBsonJavaScriptWithScope struct {
+	TypeBsonCommonToAllTypes
+}
+
+func (el *TypeBsonJavaScriptWithScope) getTypeString() string {
+	return "javascriptWithScope"
+}
+
+func (el *TypeBsonJavaScriptWithScope) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	return
+}
+
+func (el *TypeBsonJavaScriptWithScope) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("javascriptWithScope", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	return
+}
+
+func (el *TypeBsonJavaScriptWithScope) VerifyType(value interface{}) (err error) {
+	switch value.(type) {
+	case primitive.CodeWithScope:
+	default:
+		err = newViolation("bsonType", "javascriptWithScope", value, "wrong type")
+	}
+
+	return
+}
@@ -24,10 +24,12 @@ type TypeBsonLong struct {
 
 	// An integer divisor of the field value. For example, if multipleOf is set to 3, 6 is
 	// a valid value but 7 is not.
-	MultipleOf int64
+	MultipleOf       int64
+	MultipleOfHasSet bool
 
 	// The maximum value of the number.
-	Maximum int64
+	Maximum       int64
+	MaximumHasSet bool
 
 	// Default: false
 	// If true, the field value must be strictly less than the maximum value.
@@ -45,13 +47,24 @@ type TypeBsonLong struct {
 }
 
 func (el *TypeBsonLong) Verify(value interface{}) (err error) {
-	if value != nil {
-		value, err = el.TypeBsonCommonToAllTypes.parentConvertInterfaceToInt64(value)
-		if err != nil {
-			return
-		}
+	err = el.verifyNotNull("long", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyStrictNumericType("long", value)
+	if err != nil {
+		return
 	}
 
+	var converted interface{}
+	converted, err = el.TypeBsonCommonToAllTypes.parentConvertInterfaceToInt64(value)
+	if err != nil {
+		err = newViolation("bsonType", "long", value, err.Error())
+		return
+	}
+	value = converted
+
 	err = el.verifyParent(value)
 	if err != nil {
 		return
@@ -77,7 +90,8 @@ func (el *TypeBsonLong) Verify(value interface{}) (err error) {
 }
 
 func (el *TypeBsonLong) VerifyType(value interface{}) (err error) {
-	if value == nil && el.Enum.values == nil {
+	err = el.verifyNotNull("long", value)
+	if err != nil {
 		return
 	}
 
@@ -86,6 +100,9 @@ func (el *TypeBsonLong) VerifyType(value interface{}) (err error) {
 		return
 	}
 	err = el.parentVerifyInterfaceTypeIsInt64(value)
+	if err != nil {
+		err = newViolation("bsonType", "long", value, err.Error())
+	}
 	return
 }
 
@@ -95,7 +112,7 @@ func (el *TypeBsonLong) VerifyMultipleOf(value interface{}) (err error) {
 	}
 
 	var module int64
-	if el.MultipleOf == 0 {
+	if el.MultipleOfHasSet == false {
 		return
 	}
 
@@ -107,7 +124,7 @@ func (el *TypeBsonLong) VerifyMultipleOf(value interface{}) (err error) {
 
 	module = converted % el.MultipleOf
 	if module != 0 {
-		err = errors.New("number must be multiple of " + strconv.FormatInt(el.MultipleOf, 10))
+		err = newViolation("multipleOf", el.MultipleOf, converted, "number must be multiple of "+strconv.FormatInt(el.MultipleOf, 10))
 	}
 
 	return
@@ -118,7 +135,7 @@ func (el *TypeBsonLong) VerifyMaximum(value interface{}) (err error) {
 		return
 	}
 
-	if el.Maximum == 0 {
+	if el.MaximumHasSet == false {
 		return
 	}
 
@@ -129,12 +146,12 @@ func (el *TypeBsonLong) VerifyMaximum(value interface{}) (err error) {
 	}
 
 	if el.ExclusiveMaximum == true && converted >= el.Maximum {
-		err = errors.New("maximum value exceeded")
+		err = newViolation("maximum", el.Maximum, converted, "maximum value exceeded")
 		return
 	}
 
 	if el.ExclusiveMaximum == false && converted > el.Maximum {
-		err = errors.New("maximum value exceeded")
+		err = newViolation("maximum", el.Maximum, converted, "maximum value exceeded")
 		return
 	}
 
@@ -157,12 +174,12 @@ func (el *TypeBsonLong) VerifyMinimum(value interface{}) (err error) {
 	}
 
 	if el.ExclusiveMinimum == true && el.Minimum >= converted {
-		err = errors.New("expected minimum value")
+		err = newViolation("minimum", el.Minimum, converted, "expected minimum value")
 		return
 	}
 
 	if el.ExclusiveMinimum == false && el.Minimum > converted {
-		err = errors.New("expected minimum value")
+		err = newViolation("minimum", el.Minimum, converted, "expected minimum value")
 	}
 
 	return
@@ -184,16 +201,18 @@ func (el *TypeBsonLong) Populate(schema map[string]interface{}) (err error) {
 	}
 
 	var multipleOf int64
+	var multipleOfHasSet bool
 	var maximum int64
+	var maximumHasSet bool
 	var minimum int64
 	var minimumHasSet bool
 
-	multipleOf, err = el.getPropertyMultipleOf(schema)
+	multipleOfHasSet, multipleOf, err = el.getPropertyMultipleOf(schema)
 	if err != nil {
 		return
 	}
 
-	maximum, err = el.getPropertyMaximum(schema)
+	maximumHasSet, maximum, err = el.getPropertyMaximum(schema)
 	if err != nil {
 		return
 	}
@@ -213,7 +232,9 @@ func (el *TypeBsonLong) Populate(schema map[string]interface{}) (err error) {
 		return
 	}
 
+	el.MultipleOfHasSet = multipleOfHasSet
 	el.MultipleOf = multipleOf
+	el.MaximumHasSet = maximumHasSet
 	el.Maximum = maximum
 	el.Minimum = minimum
 	el.MinimumHasSet = minimumHasSet
@@ -221,7 +242,7 @@ func (el *TypeBsonLong) Populate(schema map[string]interface{}) (err error) {
 	return
 }
 
-func (el *TypeBsonLong) getPropertyMultipleOf(schema map[string]interface{}) (multipleOf int64, err error) {
+func (el *TypeBsonLong) getPropertyMultipleOf(schema map[string]interface{}) (set bool, multipleOf int64, err error) {
 	var found bool
 
 	_, found = schema["multipleOf"]
@@ -230,10 +251,20 @@ func (el *TypeBsonLong) getPropertyMultipleOf(schema map[string]interface{}) (mu
 	}
 
 	multipleOf, err = el.getPropertyAsInt64(schema, "multipleOf")
+	if err != nil {
+		return
+	}
+
+	if multipleOf <= 0 {
+		err = errors.New("multipleOf: the value must be greater than 0")
+		return
+	}
+
+	set = true
 	return
 }
 
-func (el *TypeBsonLong) getPropertyMaximum(schema map[string]interface{}) (maximum int64, err error) {
+func (el *TypeBsonLong) getPropertyMaximum(schema map[string]interface{}) (set bool, maximum int64, err error) {
 	var found bool
 
 	_, found = schema["maximum"]
@@ -241,6 +272,7 @@ func (el *TypeBsonLong) getPropertyMaximum(schema map[string]interface{}) (maxim
 		return
 	}
 
+	set = true
 	maximum, err = el.getPropertyAsInt64(schema, "maximum")
 	return
 }
@@ -0,0 +1,49 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The maxKey schema type accepts the BSON MaxKey value, represented by primitive.MaxKey.
+//
+//   Example:
+//   {
+//     "bsonType": "maxKey"
+//   }
+type TypeBsonMaxKey struct {
+	TypeBsonCommonToAllTypes
+}
+
+func (el *TypeBsonMaxKey) getTypeString() string {
+	return "maxKey"
+}
+
+func (el *TypeBsonMaxKey) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	return
+}
+
+func (el *TypeBsonMaxKey) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("maxKey", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	return
+}
+
+func (el *TypeBsonMaxKey) VerifyType(value interface{}) (err error) {
+	switch value.(type) {
+	case primitive.MaxKey:
+	default:
+		err = newViolation("bsonType", "maxKey", value, "wrong type")
+	}
+
+	return
+}
@@ -0,0 +1,49 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The minKey schema type accepts the BSON MinKey value, represented by primitive.MinKey.
+//
+//   Example:
+//   {
+//     "bsonType": "minKey"
+//   }
+type TypeBsonMinKey struct {
+	TypeBsonCommonToAllTypes
+}
+
+func (el *TypeBsonMinKey) getTypeString() string {
+	return "minKey"
+}
+
+func (el *TypeBsonMinKey) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	return
+}
+
+func (el *TypeBsonMinKey) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("minKey", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	return
+}
+
+func (el *TypeBsonMinKey) VerifyType(value interface{}) (err error) {
+	switch value.(type) {
+	case primitive.MinKey:
+	default:
+		err = newViolation("bsonType", "minKey", value, "wrong type")
+	}
+
+	return
+}
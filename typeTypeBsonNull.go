@@ -0,0 +1,38 @@
+package iotmakerdbmongodbutilschema
+
+// The null schema type accepts only the null value.
+//
+//   Example:
+//   {
+//     "bsonType": "null"
+//   }
+type TypeBsonNull struct {
+	TypeBsonCommonToAllTypes
+}
+
+func (el *TypeBsonNull) getTypeString() string {
+	return "null"
+}
+
+func (el *TypeBsonNull) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	return
+}
+
+func (el *TypeBsonNull) Verify(value interface{}) (err error) {
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	return
+}
+
+func (el *TypeBsonNull) VerifyType(value interface{}) (err error) {
+	if el.parentIsNull(value) == false {
+		err = newViolation("bsonType", "null", value, "wrong type")
+	}
+
+	return
+}
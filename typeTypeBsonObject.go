@@ -3,6 +3,7 @@ package iotmakerdbmongodbutilschema
 import (
 	"errors"
 	"reflect"
+	"sort"
 )
 
 // The object schema type configures the content of documents.
@@ -54,14 +55,26 @@ type TypeBsonObject struct {
 	// document.
 	// If the value is a schema object, any additional fields must validate against the
 	// schema
+	//
+	// Additional fields are the fields not present in properties and not matched by any
+	// regular expression of patternProperties. As in MongoDB, the '_id' field must be in
+	// properties when additionalProperties is false.
 	AdditionalPropertiesBoolIsSet bool
 	AdditionalPropertiesBoolValue bool
-	AdditionalPropertiesMap       map[string]map[string]BsonType
+	// rules of the schema document, one for each bsonType
+	AdditionalPropertiesMap map[string]BsonType
 
 	// Specify property and schema dependencies.
 	// https://www.mongodb.com/blog/post/json-schema-validation--dependencies-you-can-depend-on
+	//
+	// Schema dependencies: when the key is present, the whole object must also be valid
+	// against the schema document, indexed by bsonType.
 	Dependencies map[string]map[string]BsonType
 
+	// Property dependencies: when the key is present, all fields of the list must be
+	// present too.
+	DependenciesRequired map[string][]string
+
 	Required map[string]bool
 
 	ErrorList []error
@@ -96,10 +109,181 @@ func (el *TypeBsonObject) Populate(schema map[string]interface{}) (err error) {
 		return
 	}
 
+	el.PatternProperties, err = el.getPropertyPatternProperties(schema)
+	if err != nil {
+		return
+	}
+
+	el.AdditionalPropertiesBoolIsSet, el.AdditionalPropertiesBoolValue, el.AdditionalPropertiesMap, err = el.getPropertyAdditionalProperties(schema)
+	if err != nil {
+		return
+	}
+
+	el.DependenciesRequired, el.Dependencies, err = el.getPropertyDependencies(schema)
+	if err != nil {
+		return
+	}
+
 	el.Properties, err = el.populateBsonType(schema)
 	return
 }
 
+// getPropertyDependencies (English): Populates 'dependencies'. A list of field names is a
+// property dependency and a schema document is a schema dependency.
+//
+//   "dependencies": {
+//     "graduated": ["mailing_address"],
+//     "credit_card": { "required": ["billing_address"] }
+//   }
+//
+// getPropertyDependencies (Português): Popula 'dependencies'. Uma lista de nomes de
+// campos é uma dependência de propriedade e um documento de esquema é uma dependência de
+// esquema.
+func (el *TypeBsonObject) getPropertyDependencies(schema map[string]interface{}) (required map[string][]string, rules map[string]map[string]BsonType, err error) {
+	var found bool
+	var dependencies map[string]interface{}
+
+	_, found = schema["dependencies"]
+	if found == false {
+		return
+	}
+
+	dependencies, err = el.getPropertyAsMapStringInterface(schema, "dependencies")
+	if err != nil {
+		err = errors.New("'dependencies' key must be a document")
+		return
+	}
+
+	for key, value := range dependencies {
+		switch converted := value.(type) {
+		case []interface{}:
+			if required == nil {
+				required = make(map[string][]string)
+			}
+
+			required[key] = make([]string, 0, len(converted))
+			for _, fieldName := range converted {
+				if reflect.ValueOf(fieldName).Kind() != reflect.String {
+					err = errors.New("dependencies." + key + ": the field names must be a string")
+					return
+				}
+
+				required[key] = append(required[key], fieldName.(string))
+			}
+
+		case map[string]interface{}:
+			if rules == nil {
+				rules = make(map[string]map[string]BsonType)
+			}
+
+			rules[key], err = el.populateAlternatives(converted)
+			if err != nil {
+				err = errors.New("dependencies." + key + ": " + err.Error())
+				return
+			}
+
+		default:
+			err = errors.New("dependencies." + key + ": value must be a array of field names or a schema document")
+			return
+		}
+	}
+
+	return
+}
+
+// getPropertyAdditionalProperties (English): Populates 'additionalProperties' in the
+// boolean form or in the schema document form
+//
+// getPropertyAdditionalProperties (Português): Popula 'additionalProperties' na forma
+// booleana ou na forma de documento de esquema
+func (el *TypeBsonObject) getPropertyAdditionalProperties(schema map[string]interface{}) (boolIsSet bool, boolValue bool, rules map[string]BsonType, err error) {
+	var value interface{}
+	var found bool
+
+	value, found = schema["additionalProperties"]
+	if found == false {
+		return
+	}
+
+	switch converted := value.(type) {
+	case bool, string:
+		boolValue, err = el.getPropertyAsBool(schema, "additionalProperties")
+		if err != nil {
+			err = errors.New("'additionalProperties' key must be a boolean or a schema document")
+			return
+		}
+		boolIsSet = true
+
+	case map[string]interface{}:
+		rules, err = el.populateAlternatives(converted)
+		if err != nil {
+			err = errors.New("additionalProperties: " + err.Error())
+		}
+
+	default:
+		err = errors.New("'additionalProperties' key must be a boolean or a schema document")
+	}
+
+	return
+}
+
+// getPropertyPatternProperties (English): Populates the rules of 'patternProperties',
+// ordered by the regular expression text
+//
+// getPropertyPatternProperties (Português): Popula as regras de 'patternProperties',
+// ordenadas pelo texto da expressão regular
+func (el *TypeBsonObject) getPropertyPatternProperties(schema map[string]interface{}) (patternList []PatternProperties, err error) {
+	var found bool
+	var patternSchema map[string]interface{}
+
+	_, found = schema["patternProperties"]
+	if found == false {
+		return
+	}
+
+	patternSchema, err = el.getPropertyAsMapStringInterface(schema, "patternProperties")
+	if err != nil {
+		err = errors.New("'patternProperties' key must be a document")
+		return
+	}
+
+	var keyList = make([]string, 0, len(patternSchema))
+	for pattern := range patternSchema {
+		keyList = append(keyList, pattern)
+	}
+
+	sort.Strings(keyList)
+
+	patternList = make([]PatternProperties, 0, len(keyList))
+	for _, pattern := range keyList {
+		var patternProperties PatternProperties
+		err = patternProperties.SetRegexp(pattern)
+		if err != nil {
+			err = patternProperties.SetRegexpPOSIX(pattern)
+		}
+		if err != nil {
+			err = errors.New("patternProperties." + pattern + ": " + err.Error())
+			return
+		}
+
+		cell, isDocument := patternSchema[pattern].(map[string]interface{})
+		if isDocument == false {
+			err = errors.New("patternProperties." + pattern + ": value must be a schema document")
+			return
+		}
+
+		patternProperties.rules, err = el.populateAlternatives(cell)
+		if err != nil {
+			err = errors.New("patternProperties." + pattern + ": " + err.Error())
+			return
+		}
+
+		patternList = append(patternList, patternProperties)
+	}
+
+	return
+}
+
 // processRequiredFields (English): Process the required fields
 //    json schema example:
 //    {
@@ -245,6 +429,7 @@ func (el *TypeBsonObject) VerifyRules(value interface{}) {
 					if rule.ElementType == nil {
 						break
 					}
+					rule.ElementType.(*TypeBsonObject).ErrorList = make([]error, 0)
 					switch value.(type) {
 					case map[string]interface{}:
 						rule.ElementType.(*TypeBsonObject).VerifyRules(value.(map[string]interface{})[key])
@@ -262,19 +447,24 @@ func (el *TypeBsonObject) VerifyRules(value interface{}) {
 }
 
 func (el *TypeBsonObject) Verify(value interface{}) (err error) {
-	el.ErrorList = make([]error, 0)
+	value = el.parentConvertStructToDocument(value)
+
+	err = el.verifyNotNull("object", value)
+	if err != nil {
+		return
+	}
 
 	err = el.verifyParent(value)
 	if err != nil {
 		return
 	}
 
-	err = el.verifyMaxProperties()
+	err = el.verifyMaxProperties(value)
 	if err != nil {
 		return
 	}
 
-	err = el.verifyMinProperties()
+	err = el.verifyMinProperties(value)
 	if err != nil {
 		return
 	}
@@ -317,15 +507,132 @@ func (el *TypeBsonObject) populateBsonType(schema map[string]interface{}) (prope
 	var newSchema map[string]interface{}
 	newSchema, _ = schema["properties"].(map[string]interface{})
 	for schemaCellKey, schemaCell := range newSchema {
+		properties[schemaCellKey], err = el.populateAlternatives(schemaCell.(map[string]interface{}))
+		if err != nil {
+			err = errors.New("properties." + schemaCellKey + ": " + err.Error())
+			return
+		}
+	}
 
-		var typesInCell []string
-		typesInCell, err = el.getPropertyBsonTypeAsSlice(schemaCell.(map[string]interface{}))
+	return
+}
 
-		for _, currentType := range typesInCell {
-			err = el.typeStringToTypeObjectPopulated(&properties, schemaCellKey, currentType, schemaCell.(map[string]interface{}))
-			if err != nil {
+// populateAlternatives (English): Populates the rules of one schema document, one rule
+// for each bsonType. A schema document without 'bsonType' uses the 'generic' type.
+//
+// populateAlternatives (Português): Popula as regras de um documento de esquema, uma
+// regra para cada bsonType. Um documento de esquema sem 'bsonType' usa o tipo 'generic'.
+func (el *TypeBsonObject) populateAlternatives(schema map[string]interface{}) (alternatives map[string]BsonType, err error) {
+	var typesInCell []string
+	var properties map[string]map[string]BsonType
+
+	typesInCell, err = el.getPropertyTypeList(schema)
+	if err != nil {
+		return
+	}
+
+	if len(typesInCell) == 0 {
+		typesInCell = []string{"generic"}
+	}
+
+	for _, currentType := range typesInCell {
+		err = el.typeStringToTypeObjectPopulated(&properties, "", currentType, schema)
+		if err != nil {
+			return
+		}
+	}
+
+	alternatives = properties[""]
+	return
+}
+
+// getPropertyTypeList (English): Returns the list of bsonType of the schema document,
+// read from 'bsonType' or from the JSON 'type'. MongoDB does not allow both keys in the
+// same schema document.
+//
+// getPropertyTypeList (Português): Retorna a lista de bsonType do documento de esquema,
+// lida de 'bsonType' ou do 'type' JSON. O MongoDB não permite as duas chaves no mesmo
+// documento de esquema.
+func (el *TypeBsonObject) getPropertyTypeList(schema map[string]interface{}) (value []string, err error) {
+	_, bsonTypeFound := schema["bsonType"]
+	_, typeFound := schema["type"]
+
+	if bsonTypeFound == true && typeFound == true {
+		err = errors.New("the 'type' and 'bsonType' keys cannot be used together")
+		return
+	}
+
+	if typeFound == true {
+		value, err = el.getPropertyJsonTypeAsSlice(schema)
+		return
+	}
+
+	value, err = el.getPropertyBsonTypeAsSlice(schema)
+	return
+}
+
+// getPropertyJsonTypeAsSlice (English): Converts the JSON 'type' in the list of bsonType
+// that implements it
+//
+//   object:  object
+//   array:   array
+//   number:  int, long, double and decimal
+//   boolean: bool
+//   string:  string
+//   null:    null
+//
+// getPropertyJsonTypeAsSlice (Português): Converte o 'type' JSON na lista de bsonType
+// que o implementa
+func (el *TypeBsonObject) getPropertyJsonTypeAsSlice(schema map[string]interface{}) (value []string, err error) {
+	var jsonTypeList = make([]string, 0)
+
+	switch converted := schema["type"].(type) {
+	case string:
+		jsonTypeList = append(jsonTypeList, converted)
+	case []interface{}:
+		for _, v := range converted {
+			if reflect.ValueOf(v).Kind() != reflect.String {
+				err = errors.New("the 'type' values must be a string")
 				return
 			}
+
+			jsonTypeList = append(jsonTypeList, v.(string))
+		}
+	default:
+		err = errors.New("the 'type' a string or a array of string")
+		return
+	}
+
+	value = make([]string, 0)
+	var added = make(map[string]bool)
+	for _, jsonType := range jsonTypeList {
+		var bsonTypeList []string
+		switch jsonType {
+		case "object":
+			bsonTypeList = []string{"object"}
+		case "array":
+			bsonTypeList = []string{"array"}
+		case "number":
+			bsonTypeList = []string{"int", "long", "double", "decimal"}
+		case "boolean":
+			bsonTypeList = []string{"bool"}
+		case "string":
+			bsonTypeList = []string{"string"}
+		case "null":
+			bsonTypeList = []string{"null"}
+		case "integer":
+			err = errors.New("the JSON type 'integer' is not supported, use the 'bsonType' key with 'int' or 'long'")
+			return
+		default:
+			err = errors.New("'" + jsonType + "' is not a JSON type")
+			return
+		}
+
+		for _, bsonType := range bsonTypeList {
+			if added[bsonType] == false {
+				added[bsonType] = true
+				value = append(value, bsonType)
+			}
 		}
 	}
 
@@ -351,13 +658,13 @@ func (el *TypeBsonObject) getPropertyBsonTypeAsSlice(schema map[string]interface
 				return
 			}
 
-			value = append(value, v.(string))
+			value = el.appendBsonTypeAlias(value, v.(string))
 		}
 		return
 	}
 
 	if reflect.ValueOf(bsonType).Kind() == reflect.String {
-		value = append(value, bsonType.(string))
+		value = el.appendBsonTypeAlias(value, bsonType.(string))
 		return
 	}
 
@@ -365,6 +672,34 @@ func (el *TypeBsonObject) getPropertyBsonTypeAsSlice(schema map[string]interface
 	return
 }
 
+// appendBsonTypeAlias (English): Appends the bsonType alias to the list. The alias
+// 'number' is expanded to int, long, double and decimal, as in the $type operator.
+//
+// appendBsonTypeAlias (Português): Adiciona o alias de bsonType à lista. O alias 'number'
+// é expandido para int, long, double e decimal, como no operador $type.
+func (el *TypeBsonObject) appendBsonTypeAlias(list []string, alias string) []string {
+	var aliasList = []string{alias}
+	if alias == "number" {
+		aliasList = []string{"int", "long", "double", "decimal"}
+	}
+
+	for _, bsonType := range aliasList {
+		var found = false
+		for _, added := range list {
+			if added == bsonType {
+				found = true
+				break
+			}
+		}
+
+		if found == false {
+			list = append(list, bsonType)
+		}
+	}
+
+	return list
+}
+
 func (el *TypeBsonObject) typeStringToTypeObjectPopulated(propertiesPointer *map[string]map[string]BsonType, key string, typeString string, schema map[string]interface{}) (err error) {
 	//var newSchema map[string]interface{}
 	var objType InterfaceBson
@@ -386,92 +721,58 @@ func (el *TypeBsonObject) typeStringToTypeObjectPopulated(propertiesPointer *map
 	// nota: 'generic' foi criado por mim e não é previsto na documentação
 	case "generic":
 		objType = &TypeBsonGeneric{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
-
 	case "object":
 		objType = &TypeBsonObject{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
-
 	case "double":
 		objType = &TypeBsonDouble{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
-
 	case "string":
 		objType = &TypeBsonString{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
-
 	case "array":
 		objType = &TypeBsonArray{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
-
-	//case "binData":
-
+	case "binData":
+		objType = &TypeBsonBinData{}
+	case "null":
+		objType = &TypeBsonNull{}
 	case "objectId":
 		objType = &TypeBsonObjectId{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
-
 	case "bool":
 		objType = &TypeBsonBool{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
-
 	case "date":
 		objType = &TypeBsonDate{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
-
-		//case "null":
-	//case "regex":
-	//case "dbPointer":
-	//case "javascript":
-	//case "symbol":
-	//case "javascriptWithScope":
+	case "regex":
+		objType = &TypeBsonRegex{}
+	case "dbPointer":
+		objType = &TypeBsonDbPointer{}
+	case "javascript":
+		objType = &TypeBsonJavaScript{}
+	case "symbol":
+		objType = &TypeBsonSymbol{}
+	case "javascriptWithScope":
+		objType = &TypeBsonJavaScriptWithScope{}
+	case "minKey":
+		objType = &TypeBsonMinKey{}
+	case "maxKey":
+		objType = &TypeBsonMaxKey{}
+	case "undefined":
+		objType = &TypeBsonUndefined{}
 	case "int":
 		objType = &TypeBsonInt{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
-
 	case "timestamp":
+		objType = &TypeBsonTimestamp{}
 	case "long":
 		objType = &TypeBsonLong{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
-
 	case "decimal":
 		objType = &TypeBsonDecimal{}
-		err = objType.Populate(schema)
-		if err != nil {
-			return
-		}
 
 	default:
 		err = errors.New(typeString + ": type not implemented yet")
+		return
+	}
+
+	objType.(interfaceBsonParseOptions).setParseOptions(el.options)
+	err = objType.Populate(schema)
+	if err != nil {
+		return
 	}
 
 	if (*propertiesPointer)[key] == nil {
@@ -0,0 +1,131 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTypeBsonObject_AdditionalPropertiesFalse(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "additionalProperties": false,
+    "properties": {
+      "_id": { "bsonType": "objectId" },
+      "name": { "bsonType": "string" },
+      "address": {
+        "bsonType": "object",
+        "additionalProperties": false,
+        "properties": {
+          "street": { "bsonType": "string" }
+        },
+        "patternProperties": {
+          "^line[0-9]$": { "bsonType": "string" }
+        }
+      }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var result = schema.Validate(map[string]interface{}{
+		"_id":  primitive.NewObjectID(),
+		"name": "Dino",
+		"address": map[string]interface{}{
+			"street": "Main",
+			"line1":  "apt 1",
+		},
+	})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{
+		"name":  "Dino",
+		"email": "dino@example.com",
+		"address": map[string]interface{}{
+			"street": "Main",
+			"lineA":  "apt 1",
+			"zip":    "000",
+		},
+	})
+
+	var pathList = []string{"address.lineA", "address.zip", "email"}
+	if len(result.Violations) != len(pathList) {
+		t.Fatalf("expected %v violations, got: %v", len(pathList), result.Errors())
+	}
+
+	for k, path := range pathList {
+		if result.Violations[k].Path != path || result.Violations[k].Keyword != "additionalProperties" {
+			t.Errorf("unexpected violation: %v", result.Violations[k].Error())
+		}
+	}
+}
+
+func TestTypeBsonObject_AdditionalPropertiesSchema(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "name": { "bsonType": "string" }
+    },
+    "patternProperties": {
+      "^x-": { "bsonType": "bool" }
+    },
+    "additionalProperties": { "bsonType": "int", "minimum": 0 }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var result = schema.Validate(map[string]interface{}{
+		"name":  "Dino",
+		"x-new": true,
+		"age":   10,
+	})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{
+		"name":  "Dino",
+		"age":   -1,
+		"email": "dino@example.com",
+	})
+	if len(result.Violations) != 2 {
+		t.Fatalf("expected 2 violations, got: %v", result.Errors())
+	}
+
+	if result.Violations[0].Path != "age" || result.Violations[0].Keyword != "minimum" {
+		t.Errorf("unexpected violation: %v", result.Violations[0].Error())
+	}
+
+	if result.Violations[1].Path != "email" || result.Violations[1].Keyword != "bsonType" {
+		t.Errorf("unexpected violation: %v", result.Violations[1].Error())
+	}
+}
+
+func TestTypeBsonObject_AdditionalPropertiesTrue(t *testing.T) {
+	var schema = MongoDBJsonSchema{}
+	var err = schema.UnmarshalJSON([]byte(`{"additionalProperties": true, "properties": {}}`))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if schema.Validate(map[string]interface{}{"any": 1}).Valid() == false {
+		t.Fail()
+	}
+
+	err = schema.UnmarshalJSON([]byte(`{"additionalProperties": 1}`))
+	if err == nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,112 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTypeBsonObject_Dependencies(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "required": ["name"],
+    "properties": {
+      "name": { "bsonType": ["string"] },
+      "graduated": { "bsonType": ["bool"] }
+    },
+    "dependencies": {
+      "graduated": ["mailing_address", "phone"],
+      "credit_card": {
+        "required": ["billing_address"],
+        "properties": {
+          "billing_address": { "bsonType": ["string"] }
+        }
+      }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var validList = []map[string]interface{}{
+		{"name": "Dino"},
+		{"name": "Dino", "graduated": true, "mailing_address": "Main street", "phone": "555"},
+		{"name": "Dino", "credit_card": 1234, "billing_address": "Main street"},
+		{"name": "Dino", "billing_address": 10},
+	}
+
+	for _, document := range validList {
+		var result = schema.Validate(document)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", document, result.Errors())
+		}
+	}
+
+	var result = schema.Validate(map[string]interface{}{"name": "Dino", "graduated": true, "phone": "555"})
+	if len(result.Violations) != 1 {
+		t.Fatalf("expected 1 violation, got: %v", result.Errors())
+	}
+
+	var violation = result.Violations[0]
+	if violation.Keyword != "dependencies" || violation.Path != "mailing_address" || violation.Expected != "graduated" {
+		t.Errorf("unexpected violation: %#v", violation)
+	}
+
+	result = schema.Validate(map[string]interface{}{"name": "Dino", "credit_card": 1234, "billing_address": 10})
+	if len(result.Violations) != 1 {
+		t.Fatalf("expected 1 violation, got: %v", result.Errors())
+	}
+
+	violation = result.Violations[0]
+	if violation.Keyword != "dependencies" || violation.Path != "credit_card" || len(violation.Branches) != 1 {
+		t.Fatalf("unexpected violation: %#v", violation)
+	}
+
+	if violation.Branches[0].Violations[0].Path != "billing_address" {
+		t.Errorf("unexpected violation: %v", violation.Error())
+	}
+
+	if strings.Contains(violation.Error(), "billing_address: wrong type") == false {
+		t.Errorf("unexpected message: %v", violation.Error())
+	}
+}
+
+func TestTypeBsonObject_DependenciesNested(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "properties": {
+      "student": {
+        "bsonType": "object",
+        "dependencies": {
+          "graduated": ["mailing_address"]
+        }
+      }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var result = schema.Validate(map[string]interface{}{
+		"student": map[string]interface{}{"graduated": true},
+	})
+	if len(result.Violations) != 1 || result.Violations[0].Path != "student.mailing_address" {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	err = schema.UnmarshalJSON([]byte(`{"dependencies": {"graduated": "mailing_address"}}`))
+	if err == nil {
+		t.Fail()
+	}
+
+	err = schema.UnmarshalJSON([]byte(`{"dependencies": {"graduated": [1]}}`))
+	if err == nil {
+		t.Fail()
+	}
+}
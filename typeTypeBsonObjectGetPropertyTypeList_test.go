@@ -0,0 +1,160 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+)
+
+func TestTypeBsonObject_JsonType(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "type": "object",
+    "properties": {
+      "number": { "type": "number", "maximum": 10 },
+      "name": { "type": ["string", "null"], "maxLength": 3 },
+      "active": { "type": "boolean" },
+      "list": { "type": "array" },
+      "empty": { "type": "null" }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var validList = []map[string]interface{}{
+		{"number": 1, "name": "abc", "active": true, "empty": nil},
+		{"number": int32(2), "name": nil},
+		{"number": int64(3)},
+		{"number": 4.5},
+		{"number": float32(5)},
+		{"list": []map[string]interface{}{}},
+	}
+
+	for _, document := range validList {
+		var result = schema.Validate(document)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", document, result.Errors())
+		}
+	}
+
+	var result = schema.Validate(map[string]interface{}{"number": "1", "name": 1, "active": "true", "empty": 0})
+	if len(result.Filter("bsonType")) != 4 {
+		t.Errorf("expected 4 violations, got: %v", result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{"number": 10.5, "name": "abcd"})
+	if len(result.Violations) != 2 || len(result.Filter("maximum")) != 1 || len(result.Filter("maxLength")) != 1 {
+		t.Errorf("expected 2 violations, got: %v", result.Errors())
+	}
+}
+
+func TestTypeBsonObject_Null(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "name": { "bsonType": "string" },
+      "nick": { "bsonType": ["string", "null"] },
+      "status": { "bsonType": "string", "enum": ["on", null] },
+      "age": { "type": "number" },
+      "address": { "bsonType": "object" },
+      "tags": { "bsonType": "array" },
+      "createdAt": { "bsonType": "date" },
+      "any": { "description": "without type" }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var result = schema.Validate(map[string]interface{}{"nick": nil, "any": nil})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	// (English): as in MongoDB, null is accepted only when 'null' is one of the types
+	//
+	// (Português): como no MongoDB, null é aceito somente quando 'null' é um dos tipos
+	for _, key := range []string{"name", "status", "age", "address", "tags", "createdAt"} {
+		result = schema.Validate(map[string]interface{}{key: nil})
+		if len(result.Violations) != 1 || result.Violations[0].Path != key || result.Violations[0].Keyword != "bsonType" {
+			t.Errorf("%v: expected a bsonType violation, got: %v", key, result.Errors())
+		}
+	}
+
+	if schema.Validate(nil).Valid() == true {
+		t.Errorf("a nil document must be invalid")
+	}
+}
+
+func TestTypeBsonObject_TypedNull(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "tags": { "bsonType": "array" },
+      "address": { "bsonType": "object" },
+      "nick": { "bsonType": "string" },
+      "nullableTags": { "bsonType": ["array", "null"] },
+      "nullableAddress": { "bsonType": ["object", "null"] },
+      "nullableNick": { "bsonType": ["string", "null"] }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	// (English): the mongo driver saves nil slices, maps and pointers as null
+	//
+	// (Português): o driver do mongo grava slices, maps e ponteiros nil como null
+	var tests = []struct {
+		key         string
+		nullableKey string
+		value       interface{}
+	}{
+		{"tags", "nullableTags", []string(nil)},
+		{"address", "nullableAddress", map[string]interface{}(nil)},
+		{"nick", "nullableNick", (*string)(nil)},
+	}
+
+	for _, test := range tests {
+		var result = schema.Validate(map[string]interface{}{test.key: test.value})
+		if len(result.Violations) != 1 || result.Violations[0].Path != test.key || result.Violations[0].Keyword != "bsonType" {
+			t.Errorf("%v: expected a bsonType violation, got: %v", test.key, result.Errors())
+		}
+
+		result = schema.Validate(map[string]interface{}{test.nullableKey: test.value})
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", test.nullableKey, result.Errors())
+		}
+	}
+
+	if schema.Validate(map[string]interface{}(nil)).Valid() == true {
+		t.Errorf("a nil map document must be invalid")
+	}
+}
+
+func TestTypeBsonObject_JsonTypeErrors(t *testing.T) {
+	var schemaList = []string{
+		`{"properties": {"a": {"type": "string", "bsonType": "string"}}}`,
+		`{"properties": {"a": {"type": "integer"}}}`,
+		`{"properties": {"a": {"type": "int"}}}`,
+		`{"properties": {"a": {"type": ["string", 1]}}}`,
+		`{"properties": {"a": {"type": 1}}}`,
+	}
+
+	for _, jsonSchema := range schemaList {
+		var schema = MongoDBJsonSchema{}
+		if schema.UnmarshalJSON([]byte(jsonSchema)) == nil {
+			t.Errorf("%v: error expected", jsonSchema)
+		}
+	}
+}
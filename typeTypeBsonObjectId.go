@@ -1,7 +1,6 @@
 package iotmakerdbmongodbutilschema
 
 import (
-	"errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -19,6 +18,11 @@ type TypeBsonObjectId struct {
 }
 
 func (el *TypeBsonObjectId) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("objectId", value)
+	if err != nil {
+		return
+	}
+
 	err = el.verifyParent(value)
 	if err != nil {
 		return
@@ -33,17 +37,13 @@ func (el *TypeBsonObjectId) Verify(value interface{}) (err error) {
 }
 
 func (el *TypeBsonObjectId) VerifyType(value interface{}) (err error) {
-	if value == nil {
-		return
-	}
-
 	switch converted := value.(type) {
 	case primitive.ObjectID:
 		if converted.IsZero() == true {
-			err = errors.New("objectId not be null")
+			err = newViolation("bsonType", "objectId", value, "objectId not be null")
 		}
 	default:
-		err = errors.New("type must be a objectID")
+		err = newViolation("bsonType", "objectId", value, "type must be a objectID")
 	}
 
 	return
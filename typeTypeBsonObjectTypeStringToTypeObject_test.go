@@ -0,0 +1,73 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTypeBsonObject_TypeStringToTypeObjectAliases(t *testing.T) {
+	var tests = []struct {
+		bsonType string
+		valid    interface{}
+	}{
+		{"double", 1.5},
+		{"string", "text"},
+		{"object", map[string]interface{}{}},
+		{"array", []interface{}{}},
+		{"binData", []byte{}},
+		{"undefined", primitive.Undefined{}},
+		{"objectId", primitive.NewObjectID()},
+		{"bool", true},
+		{"null", nil},
+		{"regex", primitive.Regex{Pattern: "^a", Options: "i"}},
+		{"dbPointer", primitive.DBPointer{DB: "db.collection", Pointer: primitive.NewObjectID()}},
+		{"javascript", primitive.JavaScript("function() { return 1 }")},
+		{"symbol", primitive.Symbol("symbol")},
+		{"javascriptWithScope", primitive.CodeWithScope{Code: "function() { return x }", Scope: map[string]interface{}{"x": 1}}},
+		{"int", int32(1)},
+		{"timestamp", primitive.Timestamp{T: 1, I: 1}},
+		{"long", int64(1)},
+		{"decimal", 1.5},
+		{"minKey", primitive.MinKey{}},
+		{"maxKey", primitive.MaxKey{}},
+		{"number", int64(1)},
+	}
+
+	for _, test := range tests {
+		var schema = MongoDBJsonSchema{}
+		var err = schema.Populate(map[string]interface{}{
+			"properties": map[string]interface{}{
+				"field": map[string]interface{}{"bsonType": test.bsonType},
+			},
+		})
+		if err != nil {
+			t.Errorf("%v: error: %v", test.bsonType, err)
+			continue
+		}
+
+		var result = schema.Validate(map[string]interface{}{"field": test.valid})
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", test.bsonType, result.Errors())
+		}
+
+		if test.valid == nil {
+			continue
+		}
+
+		result = schema.Validate(map[string]interface{}{"field": complex(1, 1)})
+		if len(result.Filter("bsonType")) != 1 {
+			t.Errorf("%v: expected a bsonType violation, got: %v", test.bsonType, result.Errors())
+		}
+	}
+
+	var schema = MongoDBJsonSchema{}
+	var err = schema.Populate(map[string]interface{}{
+		"properties": map[string]interface{}{
+			"field": map[string]interface{}{"bsonType": "text"},
+		},
+	})
+	if err == nil {
+		t.Errorf("unknown bsonType must fail")
+	}
+}
@@ -0,0 +1,188 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"sort"
+)
+
+// validate (English): Validates the document and all sub documents, collecting every
+// violation found in result. It does not change the schema.
+//
+// validate (Português): Valida o documento e todos os sub documentos, coletando todas
+// as violações encontradas em result. Não altera o esquema.
+func (el *TypeBsonObject) validate(path string, value interface{}, result *ValidationResult) {
+	value = el.parentConvertStructToDocument(value)
+
+	var err error
+	err = el.verifyNotNull("object", value)
+	if err != nil {
+		result.appendError(path, value, err)
+		return
+	}
+
+	result.appendError(path, value, el.verifyParent(value))
+
+	var document documentView
+	document, err = el.parentConvertInterfaceToDocumentView(value)
+	if err != nil {
+		result.appendError(path, value, newViolation("bsonType", "object", value, err.Error()))
+		return
+	}
+
+	result.appendError(path, value, el.verifyMaxProperties(document.values))
+	result.appendError(path, value, el.verifyMinProperties(document.values))
+
+	el.validateRequired(path, document, result)
+	el.validateProperties(path, el.Properties, document, result)
+	el.validatePatternProperties(path, document, result)
+	el.validateAdditionalProperties(path, document, result)
+	el.validateDependencies(path, value, document, result)
+}
+
+// validateDependencies (English): Verifies the property and schema dependencies of the
+// keys present in the document
+//
+// validateDependencies (Português): Verifica as dependências de propriedade e de esquema
+// das chaves presentes no documento
+func (el *TypeBsonObject) validateDependencies(path string, value interface{}, document documentView, result *ValidationResult) {
+	if el.DependenciesRequired == nil && el.Dependencies == nil {
+		return
+	}
+
+	for _, key := range document.keyList {
+		for _, fieldName := range el.DependenciesRequired[key] {
+			if _, found := document.values[fieldName]; found == true {
+				continue
+			}
+
+			result.append(Violation{
+				Path:     joinPath(path, fieldName),
+				Keyword:  "dependencies",
+				Expected: key,
+				Message:  fieldName + " is required when " + key + " is present",
+			})
+		}
+
+		rules, found := el.Dependencies[key]
+		if found == false {
+			continue
+		}
+
+		var partial ValidationResult
+		el.validateProperty(path, rules, value, &partial)
+		if partial.Valid() == true {
+			continue
+		}
+
+		result.append(Violation{
+			Path:     joinPath(path, key),
+			Keyword:  "dependencies",
+			Expected: key,
+			Actual:   document.values[key],
+			Message:  "the document does not match the dependency schema of " + key,
+			Branches: []BranchViolation{{Branch: 0, Violations: partial.Violations}},
+		})
+	}
+}
+
+// validateAdditionalProperties (English): Verifies the keys of the document that are not
+// in properties and do not match any regular expression of patternProperties
+//
+// validateAdditionalProperties (Português): Verifica as chaves do documento que não
+// estão em properties e não casam com nenhuma expressão regular de patternProperties
+func (el *TypeBsonObject) validateAdditionalProperties(path string, document documentView, result *ValidationResult) {
+	if el.AdditionalPropertiesBoolIsSet == true && el.AdditionalPropertiesBoolValue == true {
+		return
+	}
+
+	if el.AdditionalPropertiesBoolIsSet == false && el.AdditionalPropertiesMap == nil {
+		return
+	}
+
+	for _, key := range document.keyList {
+		if el.isAdditionalProperty(key) == false {
+			continue
+		}
+
+		if el.AdditionalPropertiesBoolIsSet == true {
+			result.append(Violation{
+				Path:     joinPath(path, key),
+				Keyword:  "additionalProperties",
+				Expected: false,
+				Actual:   document.values[key],
+				Message:  key + " is not allowed by the schema",
+			})
+			continue
+		}
+
+		el.validateProperty(joinPath(path, key), el.AdditionalPropertiesMap, document.values[key], result)
+	}
+}
+
+// isAdditionalProperty (English): Returns true if the key is not in properties and does
+// not match any regular expression of patternProperties
+//
+// isAdditionalProperty (Português): Retorna true se a chave não está em properties e não
+// casa com nenhuma expressão regular de patternProperties
+func (el *TypeBsonObject) isAdditionalProperty(key string) bool {
+	if _, found := el.Properties[key]; found == true {
+		return false
+	}
+
+	for patternIndex := range el.PatternProperties {
+		if el.PatternProperties[patternIndex].MatchString(key) == true {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validatePatternProperties (English): Validates each key of the document against all
+// regular expressions of patternProperties that match the key name
+//
+// validatePatternProperties (Português): Valida cada chave do documento contra todas as
+// expressões regulares de patternProperties que casam com o nome da chave
+func (el *TypeBsonObject) validatePatternProperties(path string, document documentView, result *ValidationResult) {
+	if len(el.PatternProperties) == 0 {
+		return
+	}
+
+	for _, key := range document.keyList {
+		for patternIndex := range el.PatternProperties {
+			if el.PatternProperties[patternIndex].MatchString(key) == false {
+				continue
+			}
+
+			el.validateProperty(joinPath(path, key), el.PatternProperties[patternIndex].GetRules(), document.values[key], result)
+		}
+	}
+}
+
+// validateRequired (English): Verifies that all required keys are present in the
+// document
+//
+// validateRequired (Português): Verifica se todas as chaves requeridas estão presentes
+// no documento
+func (el *TypeBsonObject) validateRequired(path string, document documentView, result *ValidationResult) {
+	var keyList = make([]string, 0, len(el.Required))
+	for key, required := range el.Required {
+		if required == true {
+			keyList = append(keyList, key)
+		}
+	}
+
+	sort.Strings(keyList)
+
+	for _, key := range keyList {
+		if _, found := document.values[key]; found == true {
+			continue
+		}
+
+		result.append(Violation{
+			Path:     joinPath(path, key),
+			Keyword:  "required",
+			Expected: key,
+			Message:  key + " is required",
+		})
+	}
+}
@@ -1,10 +1,20 @@
 package iotmakerdbmongodbutilschema
 
-import "errors"
+// verifyMaxProperties (English): Verifies the number of fields of the document
+//
+// verifyMaxProperties (Português): Verifica a quantidade de campos do documento
+func (el *TypeBsonObject) verifyMaxProperties(value interface{}) (err error) {
+	if el.MaxPropertiesHasSet == false {
+		return
+	}
+
+	document, isDocument := el.parentConvertInterfaceToDocument(value)
+	if isDocument == false {
+		return
+	}
 
-func (el *TypeBsonObject) verifyMaxProperties() (err error) {
-	if el.MaxPropertiesHasSet == true && len(el.Properties) > int(el.MaxProperties) {
-		err = errors.New("maximum amount of properties exceeded")
+	if len(document) > int(el.MaxProperties) {
+		err = newViolation("maxProperties", el.MaxProperties, len(document), "maximum amount of properties exceeded")
 	}
 	return
 }
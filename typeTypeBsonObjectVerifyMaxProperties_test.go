@@ -0,0 +1,72 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+)
+
+const propertiesCountTestSchema = `
+{
+  "bsonType": "object",
+  "minProperties": 2,
+  "maxProperties": 3,
+  "properties": {
+    "tags": {
+      "bsonType": "object",
+      "maxProperties": 1
+    },
+    "list": {
+      "bsonType": "array",
+      "items": {
+        "bsonType": "object",
+        "minProperties": 1,
+        "maxProperties": 2
+      }
+    }
+  }
+}
+`
+
+func propertiesCountTestGetSchema(t *testing.T) (schema MongoDBJsonSchema) {
+	var err error
+	err = schema.UnmarshalJSON([]byte(propertiesCountTestSchema))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	return
+}
+
+func TestTypeBsonObject_VerifyMaxProperties(t *testing.T) {
+	var schema = propertiesCountTestGetSchema(t)
+
+	// the schema has two properties, but the document decides the count
+	var result = schema.Validate(map[string]interface{}{"_id": 1, "a": 1, "b": 2})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{"_id": 1, "a": 1, "b": 2, "c": 3})
+	if len(result.Violations) != 1 || result.Violations[0].Keyword != "maxProperties" || result.Violations[0].Actual != 4 {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{
+		"_id":  1,
+		"tags": map[string]interface{}{"a": 1, "b": 2},
+		"list": []map[string]interface{}{
+			{"a": 1},
+			{"a": 1, "b": 2, "c": 3},
+		},
+	})
+	if len(result.Violations) != 2 {
+		t.Fatalf("expected 2 violations, got: %v", result.Errors())
+	}
+
+	if result.Violations[0].Path != "list.1" || result.Violations[0].Keyword != "maxProperties" {
+		t.Errorf("unexpected violation: %v", result.Violations[0].Error())
+	}
+
+	if result.Violations[1].Path != "tags" || result.Violations[1].Keyword != "maxProperties" {
+		t.Errorf("unexpected violation: %v", result.Violations[1].Error())
+	}
+}
@@ -1,20 +1,27 @@
 package iotmakerdbmongodbutilschema
 
-import "errors"
+// verifyMinProperties (English): Verifies the number of fields of the document
+//
+// verifyMinProperties (Português): Verifica a quantidade de campos do documento
+func (el *TypeBsonObject) verifyMinProperties(value interface{}) (err error) {
+	if el.MinPropertiesHasSet == false {
+		return
+	}
+
+	document, isDocument := el.parentConvertInterfaceToDocument(value)
+	if isDocument == false {
+		return
+	}
 
-func (el *TypeBsonObject) verifyMinProperties() (err error) {
-	if el.MinPropertiesHasSet == true && len(el.Properties) < int(el.MinProperties) {
-		err = errors.New("minimum amount of properties not achieved")
+	if len(document) < int(el.MinProperties) {
+		err = newViolation("minProperties", el.MinProperties, len(document), "minimum amount of properties not achieved")
 	}
 	return
 }
 
 func (el *TypeBsonObject) verifyType(value ...interface{}) (err error) {
-	switch value[0].(type) {
-	case nil:
-	case map[string]interface{}:
-	default:
-		err = errors.New("wrong type")
+	if el.parentVerifyInterfaceTypeIsDocument(value[0]) != nil {
+		err = newViolation("bsonType", "object", value[0], "wrong type")
 	}
 	return
 }
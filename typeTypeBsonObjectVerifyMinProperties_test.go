@@ -0,0 +1,35 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+)
+
+func TestTypeBsonObject_VerifyMinProperties(t *testing.T) {
+	var schema = propertiesCountTestGetSchema(t)
+
+	var result = schema.Validate(map[string]interface{}{"_id": 1})
+	if len(result.Violations) != 1 || result.Violations[0].Keyword != "minProperties" || result.Violations[0].Actual != 1 {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	// empty objects inside the array are counted one by one
+	result = schema.Validate(map[string]interface{}{
+		"_id": 1,
+		"list": []map[string]interface{}{
+			{"a": 1},
+			{},
+		},
+	})
+	if len(result.Violations) != 1 || result.Violations[0].Path != "list.1" || result.Violations[0].Keyword != "minProperties" {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	var object = schema.Properties["tags"]["object"].ElementType.(*TypeBsonObject)
+	if object.Verify(map[string]interface{}{}) != nil {
+		t.Fail()
+	}
+
+	if object.Verify(map[string]interface{}{"a": 1, "b": 2}) == nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,50 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The regex schema type accepts regular expressions stored in the BSON format, represented
+// by primitive.Regex.
+//
+//   Example:
+//   {
+//     "bsonType": "regex"
+//   }
+type TypeBsonRegex struct {
+	TypeBsonCommonToAllTypes
+}
+
+func (el *TypeBsonRegex) getTypeString() string {
+	return "regex"
+}
+
+func (el *TypeBsonRegex) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	return
+}
+
+func (el *TypeBsonRegex) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("regex", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	return
+}
+
+func (el *TypeBsonRegex) VerifyType(value interface{}) (err error) {
+	switch value.(type) {
+	case primitive.Regex:
+	default:
+		err = newViolation("bsonType", "regex", value, "wrong type")
+	}
+
+	return
+}
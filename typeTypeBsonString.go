@@ -1,8 +1,8 @@
 package iotmakerdbmongodbutilschema
 
 import (
-	"errors"
 	"regexp"
+	"unicode/utf8"
 )
 
 // The string schema type configures the value of string fields.
@@ -20,16 +20,23 @@ type TypeBsonString struct {
 	TypeBsonCommonToAllTypes
 
 	// The maximum number of characters in the string.
-	MaxLength int64
+	MaxLength       int64
+	MaxLengthHasSet bool
 
 	// The minimum number of characters in the string.
-	MinLength int64
+	MinLength       int64
+	MinLengthHasSet bool
 
 	// A regular expression string that must match the string value.
 	Pattern *regexp.Regexp
 }
 
 func (el *TypeBsonString) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("string", value)
+	if err != nil {
+		return
+	}
+
 	err = el.verifyParent(value)
 	if err != nil {
 		return
@@ -59,36 +66,45 @@ func (el *TypeBsonString) Verify(value interface{}) (err error) {
 }
 
 func (el *TypeBsonString) VerifyMaxLength(value interface{}) (err error) {
-	if value == nil || el.MaxLength == 0 {
+	if value == nil || el.MaxLengthHasSet == false {
 		return
 	}
 
-	if len(value.(string)) > int(el.MaxLength) {
-		err = errors.New("maximum string size exceeded")
+	if el.countCodePoints(value) > el.MaxLength {
+		err = newViolation("maxLength", el.MaxLength, value, "maximum string size exceeded")
 	}
 
 	return
 }
 
 func (el *TypeBsonString) VerifyMinLength(value interface{}) (err error) {
-	if value == nil || el.MinLength == 0 {
+	if value == nil || el.MinLengthHasSet == false {
 		return
 	}
 
-	if len(value.(string)) < int(el.MinLength) {
-		err = errors.New("minimum string length expected")
+	if el.countCodePoints(value) < el.MinLength {
+		err = newViolation("minLength", el.MinLength, value, "minimum string length expected")
 	}
 
 	return
 }
 
+// countCodePoints (English): Returns the number of Unicode code points of the string, as
+// counted by MongoDB. "três" has 4 code points and 5 bytes.
+//
+// countCodePoints (Português): Retorna o número de code points Unicode da string, como
+// contado pelo MongoDB. "três" tem 4 code points e 5 bytes.
+func (el *TypeBsonString) countCodePoints(value interface{}) int64 {
+	return int64(utf8.RuneCountInString(value.(string)))
+}
+
 func (el *TypeBsonString) VerifyPattern(value interface{}) (err error) {
 	if value == nil || el.Pattern == nil {
 		return
 	}
 
 	if el.Pattern.MatchString(value.(string)) == false {
-		err = errors.New("the string does not match the regular expression")
+		err = newViolation("pattern", el.Pattern.String(), value, "the string does not match the regular expression")
 	}
 
 	return
@@ -97,12 +113,8 @@ func (el *TypeBsonString) VerifyPattern(value interface{}) (err error) {
 func (el *TypeBsonString) VerifyType(value interface{}) (err error) {
 	switch value.(type) {
 	case string:
-	case nil:
-		if el.Enum.values != nil {
-			err = el.Enum.Verify(value)
-		}
 	default:
-		err = errors.New("wrong type")
+		err = newViolation("bsonType", "string", value, "wrong type")
 	}
 
 	return
@@ -114,12 +126,12 @@ func (el *TypeBsonString) Populate(schema map[string]interface{}) (err error) {
 		return
 	}
 
-	el.MaxLength, err = el.getPropertyMaxLength(schema)
+	el.MaxLengthHasSet, el.MaxLength, err = el.getPropertyMaxLength(schema)
 	if err != nil {
 		return
 	}
 
-	el.MinLength, err = el.getPropertyMinLength(schema)
+	el.MinLengthHasSet, el.MinLength, err = el.getPropertyMinLength(schema)
 	if err != nil {
 		return
 	}
@@ -128,7 +140,7 @@ func (el *TypeBsonString) Populate(schema map[string]interface{}) (err error) {
 	return
 }
 
-func (el *TypeBsonString) getPropertyMaxLength(schema map[string]interface{}) (maxLength int64, err error) {
+func (el *TypeBsonString) getPropertyMaxLength(schema map[string]interface{}) (set bool, maxLength int64, err error) {
 	var found bool
 
 	_, found = schema["maxLength"]
@@ -136,11 +148,12 @@ func (el *TypeBsonString) getPropertyMaxLength(schema map[string]interface{}) (m
 		return
 	}
 
+	set = true
 	maxLength, err = el.getPropertyAsInt64(schema, "maxLength")
 	return
 }
 
-func (el *TypeBsonString) getPropertyMinLength(schema map[string]interface{}) (minLength int64, err error) {
+func (el *TypeBsonString) getPropertyMinLength(schema map[string]interface{}) (set bool, minLength int64, err error) {
 	var found bool
 
 	_, found = schema["minLength"]
@@ -148,6 +161,7 @@ func (el *TypeBsonString) getPropertyMinLength(schema map[string]interface{}) (m
 		return
 	}
 
+	set = true
 	minLength, err = el.getPropertyAsInt64(schema, "minLength")
 	return
 }
@@ -0,0 +1,43 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+)
+
+func TestTypeBsonString_VerifyLengthCodePoints(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "properties": {
+      "name": { "bsonType": "string", "minLength": 4, "maxLength": 4 }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var tests = []struct {
+		value string
+		valid bool
+	}{
+		{"três", true},
+		{"ação", true},
+		{"日本語!", true},
+		{"😀😀😀😀", true},
+		{"𝄞abc", true},
+		// e + combining circumflex accent: 5 code points
+		{"tre\u0302s", false},
+		{"três!", false},
+		{"😀😀😀", false},
+		{"abc", false},
+	}
+
+	for _, test := range tests {
+		var result = schema.Validate(map[string]interface{}{"name": test.value})
+		if result.Valid() != test.valid {
+			t.Errorf("%q (%v bytes): unexpected result: %v", test.value, len(test.value), result.Errors())
+		}
+	}
+}
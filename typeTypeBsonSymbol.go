@@ -0,0 +1,50 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The symbol schema type accepts the deprecated BSON symbol values, represented by
+// primitive.Symbol.
+//
+//   Example:
+//   {
+//     "bsonType": "symbol"
+//   }
+type TypeBsonSymbol struct {
+	TypeBsonCommonToAllTypes
+}
+
+func (el *TypeBsonSymbol) getTypeString() string {
+	return "symbol"
+}
+
+func (el *TypeBsonSymbol) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	return
+}
+
+func (el *TypeBsonSymbol) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("symbol", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	return
+}
+
+func (el *TypeBsonSymbol) VerifyType(value interface{}) (err error) {
+	switch value.(type) {
+	case primitive.Symbol:
+	default:
+		err = newViolation("bsonType", "symbol", value, "wrong type")
+	}
+
+	return
+}
@@ -0,0 +1,243 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The timestamp schema type accepts primitive.Timestamp values.
+// The values of enum, timestampMaximum and timestampMinimum are written in Extended
+// JSON, and are compared by the T component first and by the I component after.
+//
+// The keys 'timestampMaximum', 'timestampExclusiveMaximum', 'timestampMinimum' and
+// 'timestampExclusiveMinimum' are extensions of this module and are not part of the
+// MongoDB $jsonSchema, that does not apply 'maximum' and 'minimum' to timestamps.
+//
+//   Example:
+//   {
+//     "bsonType": "timestamp",
+//     "timestampMaximum": { "$timestamp": { "t": <integer>, "i": <integer> } },
+//     "timestampExclusiveMaximum": <boolean>,
+//     "timestampMinimum": { "$timestamp": { "t": <integer>, "i": <integer> } },
+//     "timestampExclusiveMinimum": <boolean>
+//   }
+type TypeBsonTimestamp struct {
+	TypeBsonCommonToAllTypes
+
+	// The maximum value of the timestamp.
+	Maximum       primitive.Timestamp
+	MaximumHasSet bool
+
+	// Default: false
+	// If true, the field value must be strictly less than the maximum value.
+	// If false, the field value may also be equal to the maximum value.
+	ExclusiveMaximum bool
+
+	// The minimum value of the timestamp.
+	Minimum       primitive.Timestamp
+	MinimumHasSet bool
+
+	// Default: false
+	// If true, the field value must be strictly greater than the minimum value.
+	// If false, the field value may also be equal to the minimum value.
+	ExclusiveMinimum bool
+}
+
+func (el *TypeBsonTimestamp) getTypeString() string {
+	return "timestamp"
+}
+
+func (el *TypeBsonTimestamp) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("timestamp", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyMaximum(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyMinimum(value)
+	return
+}
+
+func (el *TypeBsonTimestamp) VerifyType(value interface{}) (err error) {
+	switch value.(type) {
+	case primitive.Timestamp:
+	default:
+		err = newViolation("bsonType", "timestamp", value, "wrong type")
+	}
+
+	return
+}
+
+func (el *TypeBsonTimestamp) VerifyMaximum(value interface{}) (err error) {
+	if value == nil || el.MaximumHasSet == false {
+		return
+	}
+
+	var converted, ok = value.(primitive.Timestamp)
+	if ok == false {
+		err = newViolation("bsonType", "timestamp", value, "wrong type")
+		return
+	}
+
+	var compare = primitive.CompareTimestamp(converted, el.Maximum)
+	if compare > 0 || el.ExclusiveMaximum == true && compare == 0 {
+		err = newViolation("timestampMaximum", el.Maximum, value, "maximum value exceeded")
+	}
+
+	return
+}
+
+func (el *TypeBsonTimestamp) VerifyMinimum(value interface{}) (err error) {
+	if value == nil || el.MinimumHasSet == false {
+		return
+	}
+
+	var converted, ok = value.(primitive.Timestamp)
+	if ok == false {
+		err = newViolation("bsonType", "timestamp", value, "wrong type")
+		return
+	}
+
+	var compare = primitive.CompareTimestamp(converted, el.Minimum)
+	if compare < 0 || el.ExclusiveMinimum == true && compare == 0 {
+		err = newViolation("timestampMinimum", el.Minimum, value, "expected minimum value")
+	}
+
+	return
+}
+
+func (el *TypeBsonTimestamp) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	if err != nil {
+		return
+	}
+
+	for k, v := range el.Enum.values {
+		el.Enum.values[k], err = el.convertInterfaceToTimestamp(v)
+		if err != nil {
+			err = errors.New("enum: " + err.Error())
+			return
+		}
+	}
+
+	el.MaximumHasSet, el.Maximum, err = el.getPropertyTimestamp(schema, "timestampMaximum")
+	if err != nil {
+		return
+	}
+
+	el.ExclusiveMaximum, err = el.getPropertyExclusive(schema, "timestampExclusiveMaximum")
+	if err != nil {
+		return
+	}
+
+	el.MinimumHasSet, el.Minimum, err = el.getPropertyTimestamp(schema, "timestampMinimum")
+	if err != nil {
+		return
+	}
+
+	el.ExclusiveMinimum, err = el.getPropertyExclusive(schema, "timestampExclusiveMinimum")
+	return
+}
+
+func (el *TypeBsonTimestamp) getPropertyTimestamp(schema map[string]interface{}, key string) (set bool, timestamp primitive.Timestamp, err error) {
+	var found bool
+	var value interface{}
+
+	value, found = schema[key]
+	if found == false {
+		return
+	}
+
+	timestamp, err = el.convertInterfaceToTimestamp(value)
+	if err != nil {
+		err = errors.New(key + ": " + err.Error())
+		return
+	}
+
+	set = true
+	return
+}
+
+func (el *TypeBsonTimestamp) getPropertyExclusive(schema map[string]interface{}, key string) (exclusive bool, err error) {
+	var found bool
+
+	_, found = schema[key]
+	if found == false {
+		return
+	}
+
+	exclusive, err = el.getPropertyAsBool(schema, key)
+	return
+}
+
+// convertInterfaceToTimestamp (English): Converts primitive.Timestamp and the Extended
+// JSON forms {"$timestamp": {"t": 1, "i": 2}} and {"t": 1, "i": 2} into
+// primitive.Timestamp
+//
+// convertInterfaceToTimestamp (Português): Converte primitive.Timestamp e as formas do
+// Extended JSON {"$timestamp": {"t": 1, "i": 2}} e {"t": 1, "i": 2} em
+// primitive.Timestamp
+func (el *TypeBsonTimestamp) convertInterfaceToTimestamp(value interface{}) (timestamp primitive.Timestamp, err error) {
+	var found bool
+	var document map[string]interface{}
+
+	switch converted := value.(type) {
+	case primitive.Timestamp:
+		timestamp = converted
+		return
+	case map[string]interface{}:
+		document = converted
+	default:
+		err = errors.New("the timestamp must be written as {\"$timestamp\": {\"t\": <integer>, \"i\": <integer>}}")
+		return
+	}
+
+	if _, found = document["$timestamp"]; found == true {
+		document, err = el.getPropertyAsMapStringInterface(document, "$timestamp")
+		if err != nil {
+			return
+		}
+	}
+
+	var component = make([]uint32, 2)
+	for k, key := range []string{"t", "i"} {
+		var number int64
+
+		if _, found = document[key]; found == false {
+			err = errors.New("the timestamp must have the key '" + key + "'")
+			return
+		}
+
+		number, err = el.getPropertyAsInt64(document, key)
+		if err != nil {
+			return
+		}
+
+		if number < 0 || number > math.MaxUint32 {
+			err = errors.New("the key '" + key + "' of the timestamp must be between 0 and " + strconv.FormatUint(math.MaxUint32, 10))
+			return
+		}
+
+		component[k] = uint32(number)
+	}
+
+	timestamp = primitive.Timestamp{T: component[0], I: component[1]}
+	return
+}
@@ -0,0 +1,96 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTypeBsonTimestamp_Verify(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "ts": {
+        "bsonType": "timestamp",
+        "timestampMinimum": { "$timestamp": { "t": 1600000000, "i": 1 } },
+        "timestampMaximum": { "t": 1700000000, "i": 0 },
+        "timestampExclusiveMaximum": true
+      },
+      "marker": {
+        "bsonType": "timestamp",
+        "enum": [{ "$timestamp": { "t": 1, "i": 1 } }, { "$timestamp": { "t": 2, "i": 1 } }]
+      }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var validList = []map[string]interface{}{
+		{"ts": primitive.Timestamp{T: 1600000000, I: 1}},
+		{"ts": primitive.Timestamp{T: 1699999999, I: 100}},
+		{"marker": primitive.Timestamp{T: 2, I: 1}},
+	}
+
+	for _, document := range validList {
+		var result = schema.Validate(document)
+		if result.Valid() == false {
+			t.Errorf("%v: unexpected violations: %v", document, result.Errors())
+		}
+	}
+
+	var tests = []struct {
+		document map[string]interface{}
+		keyword  string
+	}{
+		{map[string]interface{}{"ts": primitive.Timestamp{T: 1600000000, I: 0}}, "timestampMinimum"},
+		{map[string]interface{}{"ts": primitive.Timestamp{T: 1700000000, I: 0}}, "timestampMaximum"},
+		{map[string]interface{}{"ts": int64(1650000000)}, "bsonType"},
+		{map[string]interface{}{"marker": primitive.Timestamp{T: 1, I: 2}}, "enum"},
+	}
+
+	for _, test := range tests {
+		var result = schema.Validate(test.document)
+		if len(result.Violations) != 1 || result.Violations[0].Keyword != test.keyword {
+			t.Errorf("%v: expected %v violation, got: %v", test.document, test.keyword, result.Errors())
+		}
+	}
+}
+
+func TestTypeBsonTimestamp_VerifyWrongType(t *testing.T) {
+	var timestamp = TypeBsonTimestamp{
+		Maximum:       primitive.Timestamp{T: 10, I: 0},
+		MaximumHasSet: true,
+		Minimum:       primitive.Timestamp{T: 1, I: 0},
+		MinimumHasSet: true,
+	}
+
+	for _, verify := range []func(interface{}) error{timestamp.VerifyMaximum, timestamp.VerifyMinimum} {
+		var err = verify(5)
+		var violation, ok = err.(Violation)
+		if ok == false || violation.Keyword != "bsonType" {
+			t.Errorf("expected bsonType violation, got: %v", err)
+		}
+	}
+}
+
+func TestTypeBsonTimestamp_PopulateError(t *testing.T) {
+	var schemaList = []string{
+		`{"properties": {"a": {"bsonType": "timestamp", "timestampMinimum": 1}}}`,
+		`{"properties": {"a": {"bsonType": "timestamp", "timestampMinimum": {"$timestamp": {"t": 1}}}}}`,
+		`{"properties": {"a": {"bsonType": "timestamp", "timestampMaximum": {"t": -1, "i": 0}}}}`,
+		`{"properties": {"a": {"bsonType": "timestamp", "timestampExclusiveMinimum": "yes"}}}`,
+		`{"properties": {"a": {"bsonType": "timestamp", "enum": ["now"]}}}`,
+	}
+
+	for _, jsonSchema := range schemaList {
+		var schema = MongoDBJsonSchema{}
+		if schema.UnmarshalJSON([]byte(jsonSchema)) == nil {
+			t.Errorf("%v: error expected", jsonSchema)
+		}
+	}
+}
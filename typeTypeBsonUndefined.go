@@ -0,0 +1,50 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The undefined schema type accepts the deprecated BSON undefined value, represented by
+// primitive.Undefined.
+//
+//   Example:
+//   {
+//     "bsonType": "undefined"
+//   }
+type TypeBsonUndefined struct {
+	TypeBsonCommonToAllTypes
+}
+
+func (el *TypeBsonUndefined) getTypeString() string {
+	return "undefined"
+}
+
+func (el *TypeBsonUndefined) Populate(schema map[string]interface{}) (err error) {
+	err = el.populateGeneric(schema)
+	return
+}
+
+func (el *TypeBsonUndefined) Verify(value interface{}) (err error) {
+	err = el.verifyNotNull("undefined", value)
+	if err != nil {
+		return
+	}
+
+	err = el.verifyParent(value)
+	if err != nil {
+		return
+	}
+
+	err = el.VerifyType(value)
+	return
+}
+
+func (el *TypeBsonUndefined) VerifyType(value interface{}) (err error) {
+	switch value.(type) {
+	case primitive.Undefined:
+	default:
+		err = newViolation("bsonType", "undefined", value, "wrong type")
+	}
+
+	return
+}
@@ -0,0 +1,94 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"testing"
+)
+
+func TestTypeBson_ZeroBounds(t *testing.T) {
+	var err error
+	var schema = MongoDBJsonSchema{}
+	err = schema.UnmarshalJSON([]byte(`
+  {
+    "bsonType": "object",
+    "properties": {
+      "int": { "bsonType": "int", "maximum": 0 },
+      "long": { "bsonType": "long", "maximum": 0, "exclusiveMaximum": true },
+      "double": { "bsonType": "double", "maximum": 0 },
+      "decimal": { "bsonType": "decimal", "maximum": 0 },
+      "generic": { "maximum": 0 },
+      "string": { "bsonType": "string", "maxLength": 0 },
+      "array": { "bsonType": "array", "maxItems": 0 },
+      "object": { "bsonType": "object", "maxProperties": 0 }
+    }
+  }
+  `))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var result = schema.Validate(map[string]interface{}{
+		"int":     -1,
+		"long":    int64(-1),
+		"double":  0.0,
+		"decimal": "-0.01",
+		"generic": -5,
+		"string":  "",
+		"array":   []interface{}{},
+		"object":  map[string]interface{}{},
+	})
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	result = schema.Validate(map[string]interface{}{
+		"int":     1,
+		"long":    int64(0),
+		"double":  0.5,
+		"decimal": "0.01",
+		"generic": 1,
+		"string":  "a",
+		"array":   []interface{}{1},
+		"object":  map[string]interface{}{"a": 1},
+	})
+
+	var tests = []struct {
+		path    string
+		keyword string
+	}{
+		{"array", "maxItems"},
+		{"decimal", "maximum"},
+		{"double", "maximum"},
+		{"generic", "maximum"},
+		{"int", "maximum"},
+		{"long", "maximum"},
+		{"object", "maxProperties"},
+		{"string", "maxLength"},
+	}
+
+	for _, test := range tests {
+		if _, found := validateTestFind(result, test.path, test.keyword); found == false {
+			t.Errorf("violation %v (%v) not found: %v", test.path, test.keyword, result.Errors())
+		}
+	}
+
+	if len(result.Violations) != len(tests) {
+		t.Errorf("expected %v violations, got: %v", len(tests), result.Errors())
+	}
+}
+
+func TestTypeBson_ZeroMultipleOf(t *testing.T) {
+	for _, bsonType := range []string{"int", "long", "double", "decimal", "number"} {
+		for _, multipleOf := range []string{"0", "-2"} {
+			var schema = MongoDBJsonSchema{}
+			var err = schema.UnmarshalJSON([]byte(`{"properties": {"a": {"bsonType": "` + bsonType + `", "multipleOf": ` + multipleOf + `}}}`))
+			if err == nil {
+				t.Errorf("%v: multipleOf %v: error expected", bsonType, multipleOf)
+			}
+		}
+	}
+
+	var schema = MongoDBJsonSchema{}
+	if schema.UnmarshalJSON([]byte(`{"properties": {"a": {"multipleOf": 0}}}`)) == nil {
+		t.Errorf("generic: error expected")
+	}
+}
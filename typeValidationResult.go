@@ -0,0 +1,55 @@
+package iotmakerdbmongodbutilschema
+
+// ValidationResult (English): Flat list of violations found while validating one
+// document. It belongs to a single call and is never stored inside the schema.
+//
+// ValidationResult (Português): Lista plana de violações encontradas durante a validação
+// de um documento. Pertence a uma única chamada e nunca é guardada dentro do esquema.
+type ValidationResult struct {
+	Violations []Violation
+}
+
+// Valid (English): Returns true when the document respects all rules of the schema
+//
+// Valid (Português): Retorna true quando o documento respeita todas as regras do esquema
+func (el ValidationResult) Valid() bool {
+	return len(el.Violations) == 0
+}
+
+// Errors (English): Returns the violations as a list of errors
+//
+// Errors (Português): Retorna as violações como uma lista de erros
+func (el ValidationResult) Errors() (errorList []error) {
+	errorList = make([]error, 0, len(el.Violations))
+	for _, violation := range el.Violations {
+		errorList = append(errorList, violation)
+	}
+
+	return
+}
+
+// Filter (English): Returns the violations of the given keyword
+//
+// Filter (Português): Retorna as violações de uma determinada palavra chave
+func (el ValidationResult) Filter(keyword string) (violationList []Violation) {
+	violationList = make([]Violation, 0)
+	for _, violation := range el.Violations {
+		if violation.Keyword == keyword {
+			violationList = append(violationList, violation)
+		}
+	}
+
+	return
+}
+
+func (el *ValidationResult) append(violation ...Violation) {
+	el.Violations = append(el.Violations, violation...)
+}
+
+func (el *ValidationResult) appendError(path string, value interface{}, err error) {
+	if err == nil {
+		return
+	}
+
+	el.append(errorToViolation(path, value, err))
+}
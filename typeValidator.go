@@ -0,0 +1,38 @@
+package iotmakerdbmongodbutilschema
+
+// Validator (English): Immutable form of a MongoDBJsonSchema, created by
+// MongoDBJsonSchema.Compile(). All functions are read only and can be called by many
+// goroutines at the same time; the errors of each call live in the returned
+// ValidationResult.
+//
+// Validator (Português): Forma imutável de um MongoDBJsonSchema, criada por
+// MongoDBJsonSchema.Compile(). Todas as funções são somente leitura e podem ser chamadas
+// por várias goroutines ao mesmo tempo; os erros de cada chamada ficam no
+// ValidationResult retornado.
+type Validator struct {
+	root TypeBsonObject
+}
+
+// Validate (English): Validates the document and returns every violation found
+//
+// Validate (Português): Valida o documento e retorna todas as violações encontradas
+func (el *Validator) Validate(document interface{}) (result ValidationResult) {
+	result.Violations = make([]Violation, 0)
+	el.root.validate("", document, &result)
+	return
+}
+
+// Verify (English): Validates the document and returns the first violation found, or
+// nil
+//
+// Verify (Português): Valida o documento e retorna a primeira violação encontrada, ou
+// nil
+func (el *Validator) Verify(document interface{}) (err error) {
+	var result = el.Validate(document)
+	if result.Valid() == true {
+		return
+	}
+
+	err = result.Violations[0]
+	return
+}
@@ -0,0 +1,123 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func validatorTestGetValidator(t *testing.T) (schema MongoDBJsonSchema, validator *Validator) {
+	var err error
+	schema = validateTestGetSchema(t)
+	validator, err = schema.Compile()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	return
+}
+
+func validatorTestDocument(index int) (document map[string]interface{}, valid bool) {
+	valid = index%2 == 0
+
+	var number = 1 + index
+	var name = "Dino"
+	if valid == false {
+		number = 0
+		name = "dino sauro rex"
+	}
+
+	document = map[string]interface{}{
+		"name": name,
+		"age":  18 + index%80,
+		"street": map[string]interface{}{
+			"name":   "Street " + strconv.Itoa(index),
+			"number": number,
+		},
+		"friends": []map[string]interface{}{
+			{"name": "Ana"},
+		},
+	}
+	return
+}
+
+func TestMongoDBJsonSchema_CompileNotPopulated(t *testing.T) {
+	var schema = MongoDBJsonSchema{}
+	_, err := schema.Compile()
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestValidator_Validate(t *testing.T) {
+	_, validator := validatorTestGetValidator(t)
+
+	var result = validator.Validate(map[string]interface{}{
+		"name":   "dino sauro rex",
+		"street": map[string]interface{}{},
+	})
+	if len(result.Violations) != 2 {
+		t.Errorf("expected 2 violations, got: %v", result.Errors())
+	}
+
+	if validator.Verify(map[string]interface{}{}) == nil {
+		t.Fail()
+	}
+
+	document, _ := validatorTestDocument(0)
+	if validator.Verify(document) != nil {
+		t.Fail()
+	}
+}
+
+func TestValidator_Immutable(t *testing.T) {
+	schema, validator := validatorTestGetValidator(t)
+
+	// a change in the schema after compile must not change the validator
+	schema.Required["age"] = true
+	schema.Properties["name"]["string"].ElementType.(*TypeBsonString).MaxLength = 1
+
+	document, _ := validatorTestDocument(0)
+	var result = validator.Validate(document)
+	if result.Valid() == false {
+		t.Errorf("unexpected violations: %v", result.Errors())
+	}
+
+	if len(schema.Validate(document).Filter("maxLength")) != 1 {
+		t.Fail()
+	}
+}
+
+func TestValidator_Concurrent(t *testing.T) {
+	schema, validator := validatorTestGetValidator(t)
+
+	var wg sync.WaitGroup
+	for routine := 0; routine != 32; routine += 1 {
+		wg.Add(1)
+		go func(routine int) {
+			defer wg.Done()
+
+			for index := routine; index != routine+200; index += 1 {
+				document, valid := validatorTestDocument(index)
+
+				var result = validator.Validate(document)
+				if result.Valid() != valid {
+					t.Errorf("document %v: expected valid %v, got: %v", index, valid, result.Errors())
+					return
+				}
+
+				if valid == false && len(result.Violations) != 2 {
+					t.Errorf("document %v: expected 2 violations, got: %v", index, result.Errors())
+					return
+				}
+
+				if schema.Validate(document).Valid() != valid {
+					t.Errorf("document %v: the schema and the validator disagree", index)
+					return
+				}
+			}
+		}(routine)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,143 @@
+package iotmakerdbmongodbutilschema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Violation (English): Describes a single rule of the schema not respected by the
+// document
+//
+//   Path:     document path of the value, as "street.number" or "list.0.name"
+//   Keyword:  schema keyword that failed, as "maxLength", "enum" or "required"
+//   Expected: constraint declared in the schema
+//   Actual:   value found in the document
+//   Message:  human readable text
+//
+// Violation (Português): Descreve uma única regra do esquema não respeitada pelo
+// documento
+//
+//   Path:     caminho do valor no documento, como "street.number" ou "list.0.name"
+//   Keyword:  palavra chave do esquema que falhou, como "maxLength", "enum" ou "required"
+//   Expected: restrição declarada no esquema
+//   Actual:   valor encontrado no documento
+//   Message:  texto legível
+type Violation struct {
+	Path     string
+	Keyword  string
+	Expected interface{}
+	Actual   interface{}
+	Message  string
+
+	// violations of each failed branch of allOf, anyOf, oneOf and not, and of the schema
+	// document of dependencies
+	Branches []BranchViolation
+}
+
+// BranchViolation (English): Violations of one branch of allOf, anyOf, oneOf and not.
+// Branch is the position of the schema document inside the keyword array.
+//
+// BranchViolation (Português): Violações de um ramo de allOf, anyOf, oneOf e not. Branch
+// é a posição do documento de esquema dentro do array da palavra chave.
+type BranchViolation struct {
+	Branch     int
+	Violations []Violation
+}
+
+// Error (English): Implements the error interface
+//
+// Error (Português): Implementa a interface error
+func (el Violation) Error() string {
+	var message = el.Message
+
+	if len(el.Branches) != 0 {
+		var branchList = make([]string, 0, len(el.Branches))
+		for _, branch := range el.Branches {
+			var reasonList = make([]string, 0, len(branch.Violations))
+			for _, reason := range branch.Violations {
+				reasonList = append(reasonList, reason.Error())
+			}
+
+			branchList = append(branchList, fmt.Sprintf("branch %v: %v", branch.Branch, strings.Join(reasonList, ", ")))
+		}
+
+		message = fmt.Sprintf("%v (%v)", message, strings.Join(branchList, "; "))
+	}
+
+	if el.Path == "" {
+		return message
+	}
+
+	return fmt.Sprintf("%v: %v", el.Path, message)
+}
+
+// newViolation (English): Returns a violation without path. The path is filled in by
+// the validation walker.
+//
+// newViolation (Português): Retorna uma violação sem caminho. O caminho é preenchido
+// por quem percorre a validação.
+func newViolation(keyword string, expected, actual interface{}, message string) (err error) {
+	return Violation{
+		Keyword:  keyword,
+		Expected: expected,
+		Actual:   actual,
+		Message:  message,
+	}
+}
+
+// errorToViolation (English): Converts any error returned by a Verify() function into a
+// violation placed in the given path
+//
+// errorToViolation (Português): Converte qualquer erro retornado por uma função
+// Verify() em uma violação colocada no caminho informado
+func errorToViolation(path string, value interface{}, err error) (violation Violation) {
+	if errors.As(err, &violation) == false {
+		violation = Violation{
+			Actual:  value,
+			Message: err.Error(),
+		}
+	}
+
+	violation = violation.rebase(path)
+	return
+}
+
+// rebase (English): Places the violation, and the violations of its branches, inside
+// the given path
+//
+// rebase (Português): Coloca a violação, e as violações dos seus ramos, dentro do
+// caminho informado
+func (el Violation) rebase(path string) Violation {
+	el.Path = joinPath(path, el.Path)
+
+	if len(el.Branches) != 0 {
+		var branchList = make([]BranchViolation, len(el.Branches))
+		for branchIndex, branch := range el.Branches {
+			branchList[branchIndex].Branch = branch.Branch
+			branchList[branchIndex].Violations = make([]Violation, len(branch.Violations))
+			for violationIndex, violation := range branch.Violations {
+				branchList[branchIndex].Violations[violationIndex] = violation.rebase(path)
+			}
+		}
+		el.Branches = branchList
+	}
+
+	return el
+}
+
+// joinPath (English): Joins two parts of a document path using dot notation
+//
+// joinPath (Português): Junta duas partes de um caminho de documento usando a notação
+// de ponto
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	if key == "" {
+		return path
+	}
+
+	return path + "." + key
+}